@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/picatz/rsalint/rsacheck"
+)
+
+// rulesVersion is the version of the rule set that baselines are written with, and
+// must match to be applied. It's a variable so tests can change it.
+var rulesVersion = rsacheck.RulesVersion
+
+// baseline is a set of known findings that are suppressed, so that only new findings
+// are reported, such as when adopting the analyzer in an existing code base.
+type baseline struct {
+	// Version is the version of the rule set the baseline was written with.
+	Version int `json:"version"`
+
+	// Findings are the suppressed findings.
+	Findings []baselineFinding `json:"findings"`
+}
+
+// baselineFinding identifies a finding in a baseline. Line and column numbers are
+// omitted, so findings remain suppressed when unrelated code above them changes.
+type baselineFinding struct {
+	File    string `json:"file"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// newBaselineFinding returns the baseline entry of the given finding, with its file
+// relative to the working directory.
+func newBaselineFinding(f finding) baselineFinding {
+	file := f.posn.Filename
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, file); err == nil {
+			file = rel
+		}
+	}
+
+	return baselineFinding{
+		File:    filepath.ToSlash(file),
+		Rule:    f.ruleID,
+		Message: f.message,
+	}
+}
+
+// writeBaseline writes the given findings as a baseline to the file at the given path.
+func writeBaseline(path string, fs []finding) error {
+	b := baseline{Version: rulesVersion, Findings: []baselineFinding{}}
+	for _, f := range fs {
+		b.Findings = append(b.Findings, newBaselineFinding(f))
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// applyBaseline returns the findings that aren't in the baseline at the given path.
+// Each entry in the baseline suppresses a single finding.
+//
+// A baseline written with another version of the rule set is stale, so it's ignored
+// with a warning, and all findings are returned.
+func applyBaseline(path string, fs []finding, stderr io.Writer) ([]finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var b baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if b.Version != rulesVersion {
+		fmt.Fprintf(stderr, "%s: ignoring baseline %s written with rule set version %d, the current version is %d\n", rsacheck.Analyzer.Name, path, b.Version, rulesVersion)
+		return fs, nil
+	}
+
+	known := map[baselineFinding]int{}
+	for _, entry := range b.Findings {
+		known[entry]++
+	}
+
+	var filtered []finding
+	for _, f := range fs {
+		entry := newBaselineFinding(f)
+		if known[entry] > 0 {
+			known[entry]--
+			continue
+		}
+		filtered = append(filtered, f)
+	}
+
+	return filtered, nil
+}
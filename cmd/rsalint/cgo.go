@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// cgoMode is the value of the -cgo flag.
+type cgoMode string
+
+// Cgo modes.
+const (
+	cgoAuto cgoMode = "auto"
+	cgoOn   cgoMode = "on"
+	cgoOff  cgoMode = "off"
+)
+
+func (m *cgoMode) String() string {
+	return string(*m)
+}
+
+func (m *cgoMode) Set(s string) error {
+	switch mode := cgoMode(s); mode {
+	case cgoAuto, cgoOn, cgoOff:
+		*m = mode
+		return nil
+	}
+	return fmt.Errorf("must be auto, on, or off")
+}
+
+// env returns the environment packages are loaded with. In auto mode, it's nil, so the
+// environment of the command is used, and cgo is enabled as by the go command: if the
+// CGO_ENABLED environment variable isn't set, only when a C compiler is found. Otherwise,
+// CGO_ENABLED is overridden, so files that import "C" are consistently included or not.
+func (m cgoMode) env() []string {
+	switch m {
+	case cgoOn:
+		return append(os.Environ(), "CGO_ENABLED=1")
+	case cgoOff:
+		return append(os.Environ(), "CGO_ENABLED=0")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/picatz/rsalint/rsacheck"
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences used to color findings.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// colorMode is the value of the -color flag.
+type colorMode string
+
+// Color modes.
+const (
+	colorAuto   colorMode = "auto"
+	colorAlways colorMode = "always"
+	colorNever  colorMode = "never"
+)
+
+func (m *colorMode) String() string {
+	return string(*m)
+}
+
+func (m *colorMode) Set(s string) error {
+	switch mode := colorMode(s); mode {
+	case colorAuto, colorAlways, colorNever:
+		*m = mode
+		return nil
+	}
+	return fmt.Errorf("must be auto, always, or never")
+}
+
+// enabled reports whether findings written to w should be colored. In auto mode,
+// they're only colored if w is a terminal and the NO_COLOR environment variable
+// isn't set, so escape sequences never leak into piped or redirected output.
+func (m colorMode) enabled(w io.Writer) bool {
+	switch m {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// colorize wraps the text in the given escape sequence, if enabled.
+func colorize(enabled bool, code, text string) string {
+	if !enabled {
+		return text
+	}
+	return code + text + ansiReset
+}
+
+// severityColor returns the escape sequence used to color the given severity.
+func severityColor(sev rsacheck.Severity) string {
+	switch sev {
+	case rsacheck.SeverityWarning:
+		return ansiYellow
+	case rsacheck.SeverityInfo:
+		return ansiCyan
+	}
+	return ansiRed
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/picatz/rsalint/rsacheck"
+	"gopkg.in/yaml.v3"
+)
+
+// configFile is the name of the configuration file, which can be placed in any
+// directory of a module to configure the findings for files in that directory,
+// and its subdirectories.
+const configFile = ".rsalint.yml"
+
+// config is the configuration for the files in a directory.
+type config struct {
+	// Rules enables or disables rules, by ID (RSA001) or category (weak-random).
+	Rules map[string]bool `yaml:"rules"`
+
+	// Severity overrides the severity of rules, by ID or category.
+	Severity map[string]rsacheck.Severity `yaml:"severity"`
+}
+
+// merge returns the configuration with the settings of the other configuration,
+// from a nested directory, taking precedence.
+func (c config) merge(other config) config {
+	merged := config{Rules: map[string]bool{}, Severity: map[string]rsacheck.Severity{}}
+	for name, enabled := range c.Rules {
+		merged.Rules[name] = enabled
+	}
+	for name, enabled := range other.Rules {
+		merged.Rules[name] = enabled
+	}
+	for name, sev := range c.Severity {
+		merged.Severity[name] = sev
+	}
+	for name, sev := range other.Severity {
+		merged.Severity[name] = sev
+	}
+	return merged
+}
+
+// enabled reports whether the rule of the given finding is enabled. A rule's ID
+// takes precedence over its category, and rules are enabled by default.
+func (c config) enabled(f finding) bool {
+	if enabled, ok := c.Rules[f.ruleID]; ok {
+		return enabled
+	}
+
+	if rule, ok := rsacheck.LookupRule(f.ruleID); ok {
+		if enabled, ok := c.Rules[rule.Category]; ok {
+			return enabled
+		}
+	}
+
+	return true
+}
+
+// severity returns the severity of the given finding. A rule's ID takes
+// precedence over its category, and findings have the default severity of their
+// rule otherwise.
+func (c config) severity(f finding) rsacheck.Severity {
+	if sev, ok := c.Severity[f.ruleID]; ok {
+		return sev
+	}
+
+	if rule, ok := rsacheck.LookupRule(f.ruleID); ok {
+		if sev, ok := c.Severity[rule.Category]; ok {
+			return sev
+		}
+	}
+
+	return ruleSeverity(f.ruleID)
+}
+
+// ruleSeverity returns the default severity of the rule with the given ID. Findings
+// of unknown rules are errors. Only findings with an error severity result in a
+// non-zero exit code.
+func ruleSeverity(id string) rsacheck.Severity {
+	if rule, ok := rsacheck.LookupRule(id); ok {
+		return rule.Severity
+	}
+	return rsacheck.SeverityError
+}
+
+// configs resolves the effective configuration for files, which is the merge of
+// all configuration files from the module root down to the file's directory, with
+// the nearest file taking precedence.
+type configs struct {
+	dirs map[string]config
+}
+
+// forFile returns the effective configuration for the given file.
+func (cs *configs) forFile(filename string) (config, error) {
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		return config{}, err
+	}
+	return cs.forDir(dir)
+}
+
+// forDir returns the effective configuration for the given absolute directory.
+func (cs *configs) forDir(dir string) (config, error) {
+	if c, ok := cs.dirs[dir]; ok {
+		return c, nil
+	}
+
+	var parent config
+
+	// Walk up to the module root, or the root of the file system.
+	if !exists(filepath.Join(dir, "go.mod")) && filepath.Dir(dir) != dir {
+		var err error
+		parent, err = cs.forDir(filepath.Dir(dir))
+		if err != nil {
+			return config{}, err
+		}
+	}
+
+	own, err := readConfig(filepath.Join(dir, configFile))
+	if err != nil {
+		return config{}, err
+	}
+
+	if cs.dirs == nil {
+		cs.dirs = map[string]config{}
+	}
+	cs.dirs[dir] = parent.merge(own)
+
+	return cs.dirs[dir], nil
+}
+
+// readConfig reads the configuration file at the given path. A missing file
+// results in an empty configuration.
+func readConfig(path string) (config, error) {
+	var c config
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	} else if err != nil {
+		return c, err
+	}
+
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for name, sev := range c.Severity {
+		if !sev.Valid() {
+			return c, fmt.Errorf("%s: unknown severity %q for %s", path, sev, name)
+		}
+	}
+
+	return c, nil
+}
+
+// exists reports whether the given file exists.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// applyConfig returns the findings whose rules are enabled by the configuration
+// for their files, with their severity set by the configuration.
+func applyConfig(fs []finding) ([]finding, error) {
+	var (
+		cs       configs
+		filtered []finding
+	)
+
+	for _, f := range fs {
+		c, err := cs.forFile(f.posn.Filename)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.enabled(f) {
+			f.severity = c.severity(f)
+			filtered = append(filtered, f)
+		}
+	}
+
+	return filtered, nil
+}
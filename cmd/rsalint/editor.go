@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/picatz/rsalint/rsacheck"
+)
+
+// golangciPlugin is the name the analyzer is registered with as a golangci-lint
+// module plugin, and the import path of the package that registers it.
+const (
+	golangciPlugin       = "rsalint"
+	golangciPluginImport = "github.com/picatz/rsalint/golangci"
+	golangciVersion      = "v1.62.2"
+)
+
+// editorFile is a configuration file generated for an editor or tool.
+type editorFile struct {
+	name    string
+	content string
+}
+
+// editors are the editors and tools that configuration can be generated for, in the
+// order they're printed.
+var editors = []string{"vscode", "golangci-lint"}
+
+// editorFiles returns the configuration files for the given editor, wiring the
+// rsalint binary at the given path as a custom analyzer.
+func editorFiles(editor, rsalint string) ([]editorFile, error) {
+	switch editor {
+	case "vscode":
+		// The Go extension runs "go vet" on save, which rsalint supports as a -vettool.
+		settings, err := json.MarshalIndent(map[string]any{
+			"go.vetOnSave": "package",
+			"go.vetFlags":  []string{"-vettool=" + rsalint},
+		}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+
+		return []editorFile{{name: filepath.Join(".vscode", "settings.json"), content: string(settings) + "\n"}}, nil
+	case "golangci-lint":
+		return []editorFile{
+			{
+				name: ".custom-gcl.yml",
+				content: fmt.Sprintf(`# Build a custom golangci-lint binary with rsalint using "golangci-lint custom".
+version: %s
+plugins:
+  - module: github.com/picatz/rsalint
+    import: %s
+`, golangciVersion, golangciPluginImport),
+			},
+			{
+				name: ".golangci.yml",
+				content: fmt.Sprintf(`linters-settings:
+  custom:
+    %s:
+      type: module
+      description: Reports insecure usage of the crypto/rsa package.
+linters:
+  enable:
+    - %s
+`, golangciPlugin, golangciPlugin),
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown editor %q, expected one of %v", editor, editors)
+}
+
+// initEditor runs the "init-editor" subcommand, which prints sample configuration for
+// the given editors and tools, or all of them, wiring rsalint as a custom analyzer.
+// With -write, the files are written to the working directory instead, without
+// overwriting existing files.
+func initEditor(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet(rsacheck.Analyzer.Name+" init-editor", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	write := fs.Bool("write", false, "write the configuration files to the working directory, instead of printing them")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 1
+	}
+
+	selected := fs.Args()
+	if len(selected) == 0 {
+		selected = editors
+	}
+
+	rsalint, err := os.Executable()
+	if err != nil {
+		rsalint = rsacheck.Analyzer.Name
+	}
+
+	var files []editorFile
+	for _, editor := range selected {
+		ef, err := editorFiles(editor, rsalint)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+			return 1
+		}
+		files = append(files, ef...)
+	}
+
+	if !*write {
+		for i, f := range files {
+			if i > 0 {
+				fmt.Fprintln(stdout)
+			}
+			fmt.Fprintf(stdout, "# %s\n%s", filepath.ToSlash(f.name), f.content)
+		}
+		return 0
+	}
+
+	// Check every file first, so that nothing is written if any file exists.
+	for _, f := range files {
+		if _, err := os.Stat(f.name); err == nil {
+			fmt.Fprintf(stderr, "%s: %s already exists\n", rsacheck.Analyzer.Name, f.name)
+			return 1
+		}
+	}
+
+	for _, f := range files {
+		if dir := filepath.Dir(f.name); dir != "." {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+				return 1
+			}
+		}
+
+		if err := os.WriteFile(f.name, []byte(f.content), 0o644); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "wrote %s\n", filepath.ToSlash(f.name))
+	}
+
+	return 0
+}
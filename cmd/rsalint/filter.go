@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"golang.org/x/tools/go/packages"
+)
+
+// globList is a list of glob patterns, set by repeating a flag or separating patterns
+// with commas, implementing flag.Value.
+type globList []string
+
+func (l *globList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *globList) Set(s string) error {
+	for _, pattern := range strings.Split(s, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+
+		if !doublestar.ValidatePattern(pattern) {
+			return fmt.Errorf("invalid pattern %q", pattern)
+		}
+
+		*l = append(*l, pattern)
+	}
+	return nil
+}
+
+// match reports whether the given file matches one of the patterns. Patterns are
+// matched against the file's path relative to the working directory, using forward
+// slashes, and "**" matches any number of directories.
+func (l globList) match(filename string) bool {
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, filename); err == nil {
+			filename = rel
+		}
+	}
+	filename = filepath.ToSlash(filename)
+
+	for _, pattern := range l {
+		if ok, _ := doublestar.Match(pattern, filename); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// filterIncluded returns the findings in files matching one of the include patterns,
+// or all findings if there are no patterns.
+func filterIncluded(fs []finding, include globList) []finding {
+	if len(include) == 0 {
+		return fs
+	}
+
+	var filtered []finding
+	for _, f := range fs {
+		if include.match(f.posn.Filename) {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
+
+// filterGenerated returns the findings that aren't in generated files of the given
+// packages, which have a "// Code generated ... DO NOT EDIT." comment.
+func filterGenerated(fs []finding, pkgs []*packages.Package) []finding {
+	generated := map[string]bool{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			if ast.IsGenerated(file) {
+				generated[pkg.Fset.File(file.Pos()).Name()] = true
+			}
+		}
+	}
+
+	var filtered []finding
+	for _, f := range fs {
+		if !generated[f.posn.Filename] {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
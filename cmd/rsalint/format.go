@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/picatz/rsalint/rsacheck"
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// finding is a diagnostic reported by the analyzer, resolved to its position.
+type finding struct {
+	pkgPath  string
+	posn     token.Position
+	ruleID   string
+	message  string
+	severity rsacheck.Severity
+	related  []related
+}
+
+// related is a secondary position of a finding, such as where a weak key is used.
+type related struct {
+	posn    token.Position
+	message string
+}
+
+// findings returns the diagnostics reported for the root packages of the graph.
+//
+// Diagnostics are de-duplicated by position and message, since files can belong
+// to more than one package, such as a package and its test variant.
+func findings(graph *checker.Graph) []finding {
+	type key struct {
+		posn, end token.Position
+		message   string
+	}
+
+	var (
+		seen   = map[key]bool{}
+		result []finding
+	)
+
+	for _, act := range graph.Roots {
+		for _, diag := range act.Diagnostics {
+			posn := act.Package.Fset.Position(diag.Pos)
+
+			k := key{posn, act.Package.Fset.Position(diag.End), diag.Message}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+
+			f := finding{
+				pkgPath:  act.Package.PkgPath,
+				posn:     posn,
+				ruleID:   diag.Category,
+				message:  diag.Message,
+				severity: ruleSeverity(diag.Category),
+			}
+
+			for _, rel := range diag.Related {
+				f.related = append(f.related, related{
+					posn:    act.Package.Fset.Position(rel.Pos),
+					message: rel.Message,
+				})
+			}
+
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
+
+// sortFindings sorts the findings by file, line, column, and rule ID, so the output
+// is reproducible across runs. Ties are broken by the message.
+func sortFindings(fs []finding) {
+	slices.SortStableFunc(fs, func(a, b finding) int {
+		return cmp.Or(
+			cmp.Compare(a.posn.Filename, b.posn.Filename),
+			cmp.Compare(a.posn.Line, b.posn.Line),
+			cmp.Compare(a.posn.Column, b.posn.Column),
+			cmp.Compare(a.ruleID, b.ruleID),
+			cmp.Compare(a.message, b.message),
+		)
+	})
+}
+
+// printText prints the findings as plain text, one per line. Findings with a
+// severity other than error are prefixed with it. If context is non-negative,
+// the offending line is printed along with that many lines of context before
+// and after it. If color is set, positions and severities are colored.
+func printText(w io.Writer, fs []finding, context int, color bool) {
+	for _, f := range fs {
+		posn := colorize(color, ansiBold, f.posn.String())
+
+		if f.severity != rsacheck.SeverityError {
+			fmt.Fprintf(w, "%s: %s: %s\n", posn, colorize(color, severityColor(f.severity), string(f.severity)), f.message)
+		} else {
+			fmt.Fprintf(w, "%s: %s\n", posn, f.message)
+		}
+
+		if context >= 0 {
+			printContext(w, f.posn, context)
+		}
+	}
+}
+
+// printShort prints the findings in a compact format, one per line, as
+// "file:line:col: [RULEID] message", with the file relative to the working
+// directory when possible.
+func printShort(w io.Writer, fs []finding) {
+	wd, _ := os.Getwd()
+
+	for _, f := range fs {
+		fmt.Fprintf(w, "%s:%d:%d: [%s] %s\n", relativePath(wd, f.posn.Filename), f.posn.Line, f.posn.Column, f.ruleID, f.message)
+	}
+}
+
+// flatFinding is a finding in the -format=flat output.
+type flatFinding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	RuleID   string `json:"ruleID"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+// printFlat prints the findings as a flat JSON array, which is simpler to consume
+// than the versioned report, such as by scripts using jq.
+func printFlat(w io.Writer, fs []finding) error {
+	wd, _ := os.Getwd()
+
+	flat := make([]flatFinding, 0, len(fs))
+	for _, f := range fs {
+		flat = append(flat, flatFinding{
+			File:     relativePath(wd, f.posn.Filename),
+			Line:     f.posn.Line,
+			Column:   f.posn.Column,
+			RuleID:   f.ruleID,
+			Message:  f.message,
+			Severity: string(f.severity),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(flat)
+}
+
+// printContext prints the lines surrounding the given position.
+func printContext(w io.Writer, posn token.Position, context int) {
+	file, err := os.Open(posn.Filename)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if line >= posn.Line-context && line <= posn.Line+context {
+			fmt.Fprintf(w, "%d\t%s\n", line, scanner.Text())
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+
+	"golang.org/x/tools/go/analysis/checker"
+)
+
+// jsonTree is the -json output, mapping package IDs to analyzer names to either a
+// list of diagnostics, or an error. It matches the output of the standard analysis
+// drivers, with the addition of each diagnostic's package path.
+type jsonTree map[string]map[string]any
+
+// jsonDiagnostic is a diagnostic in the -json output.
+type jsonDiagnostic struct {
+	Category       string             `json:"category,omitempty"`
+	Package        string             `json:"package"`
+	Posn           string             `json:"posn"`
+	Message        string             `json:"message"`
+	SuggestedFixes []jsonSuggestedFix `json:"suggested_fixes,omitempty"`
+	Related        []jsonRelated      `json:"related,omitempty"`
+}
+
+// jsonSuggestedFix is a suggested fix of a diagnostic in the -json output.
+type jsonSuggestedFix struct {
+	Message string         `json:"message"`
+	Edits   []jsonTextEdit `json:"edits"`
+}
+
+// jsonTextEdit is an edit of a suggested fix, where Start and End are byte offsets.
+type jsonTextEdit struct {
+	Filename string `json:"filename"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	New      string `json:"new"`
+}
+
+// jsonRelated is related information of a diagnostic in the -json output.
+type jsonRelated struct {
+	Posn    string `json:"posn"`
+	Message string `json:"message"`
+}
+
+// jsonError is an analysis error in the -json output.
+type jsonError struct {
+	Err string `json:"error"`
+}
+
+// printJSON prints the diagnostics of the root packages of the graph that are among
+// the given findings, and the errors of all packages, as JSON. Diagnostics filtered
+// out of the findings, such as by a config file or a baseline, aren't printed.
+func printJSON(w io.Writer, graph *checker.Graph, results []finding) error {
+	type key struct {
+		posn    token.Position
+		message string
+	}
+
+	kept := map[key]bool{}
+	for _, f := range results {
+		kept[key{f.posn, f.message}] = true
+	}
+
+	tree := jsonTree{}
+
+	for act := range graph.All() {
+		var v any
+
+		switch {
+		case act.Err != nil:
+			v = jsonError{act.Err.Error()}
+		case act.IsRoot && len(act.Diagnostics) > 0:
+			fset := act.Package.Fset
+
+			var diags []jsonDiagnostic
+			for _, diag := range act.Diagnostics {
+				if !kept[key{fset.Position(diag.Pos), diag.Message}] {
+					continue
+				}
+
+				d := jsonDiagnostic{
+					Category: diag.Category,
+					Package:  act.Package.PkgPath,
+					Posn:     fset.Position(diag.Pos).String(),
+					Message:  diag.Message,
+				}
+
+				for _, fix := range diag.SuggestedFixes {
+					f := jsonSuggestedFix{Message: fix.Message}
+					for _, edit := range fix.TextEdits {
+						f.Edits = append(f.Edits, jsonTextEdit{
+							Filename: fset.Position(edit.Pos).Filename,
+							Start:    fset.Position(edit.Pos).Offset,
+							End:      fset.Position(edit.End).Offset,
+							New:      string(edit.NewText),
+						})
+					}
+					d.SuggestedFixes = append(d.SuggestedFixes, f)
+				}
+
+				for _, rel := range diag.Related {
+					d.Related = append(d.Related, jsonRelated{
+						Posn:    fset.Position(rel.Pos).String(),
+						Message: rel.Message,
+					})
+				}
+
+				diags = append(diags, d)
+			}
+			if len(diags) == 0 {
+				continue
+			}
+			v = diags
+		default:
+			continue
+		}
+
+		if tree[act.Package.ID] == nil {
+			tree[act.Package.ID] = map[string]any{}
+		}
+		tree[act.Package.ID][act.Analyzer.Name] = v
+	}
+
+	data, err := json.MarshalIndent(tree, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
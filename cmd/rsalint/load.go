@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// load loads the packages matching the given patterns, with the syntax and
+// type information required by the analyzer. Dependencies are type-checked
+// from source, rather than export data, so the command is not tied to the
+// export data format of a particular Go toolchain.
+//
+// Cgo is enabled according to the -cgo flag, so that packages can be loaded
+// consistently in environments without a C toolchain, using -cgo=off.
+//
+// If a module path is given, only packages belonging to that module are
+// returned. This is useful in Go workspaces (go.work), where patterns such
+// as ./... can span multiple modules.
+func load(opts options, patterns []string) ([]*packages.Package, error) {
+	cfg := &packages.Config{
+		Mode:  packages.LoadAllSyntax | packages.NeedModule,
+		Tests: opts.tests,
+		Env:   opts.cgo.env(),
+	}
+
+	if opts.tags != "" {
+		cfg.BuildFlags = []string{"-tags=" + opts.tags}
+	}
+
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	if n := packages.PrintErrors(pkgs); n > 1 {
+		return nil, fmt.Errorf("%d errors during loading", n)
+	} else if n == 1 {
+		return nil, fmt.Errorf("error during loading")
+	}
+
+	if opts.module != "" {
+		pkgs = filterModule(pkgs, opts.module)
+	}
+
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("%s matched no packages", strings.Join(patterns, " "))
+	}
+
+	return pkgs, nil
+}
+
+// filterModule returns the packages that belong to the module with the given path.
+func filterModule(pkgs []*packages.Package, path string) []*packages.Package {
+	var filtered []*packages.Package
+	for _, pkg := range pkgs {
+		if pkg.Module != nil && pkg.Module.Path == path {
+			filtered = append(filtered, pkg)
+		}
+	}
+	return filtered
+}
@@ -1,10 +1,294 @@
+// Command rsalint reports insecure usage of the "crypto/rsa" package.
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
 	"github.com/picatz/rsalint/rsacheck"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
 	"golang.org/x/tools/go/analysis/singlechecker"
 )
 
 func main() {
-	singlechecker.Main(rsacheck.Analyzer)
+	// The "go vet -vettool" protocol is handled by the standard driver.
+	if vetTool(os.Args[1:]) {
+		if err := redirectVetStdout(os.Args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+			os.Exit(1)
+		}
+		singlechecker.Main(rsacheck.Analyzer)
+		return
+	}
+
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// options for a single run of the command, set using flags.
+type options struct {
+	json    bool
+	format  string
+	context int
+	color   colorMode
+	tests   bool
+	module  string
+	sort    bool
+	tags    string
+	cgo     cgoMode
+	include globList
+
+	prodOnly      bool
+	skipGenerated bool
+	since         sinceTime
+
+	maxFindings int
+
+	sqlite string
+
+	checkUpdate bool
+
+	cpuprofile string
+	memprofile string
+
+	baseline      string
+	writeBaseline bool
+}
+
+// run runs the analyzer on the packages given as arguments, and returns the exit
+// code: 0 if there were no findings, 1 if there were errors, and 3 if there were
+// findings with an error severity, or more findings than -max-findings, matching
+// the standard analysis drivers.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 {
+		switch args[0] {
+		case "selftest":
+			return selftest(stdout, stderr)
+		case "serve":
+			return serve(args[1:], stderr)
+		case "init-editor":
+			return initEditor(args[1:], stdout, stderr)
+		case "rules":
+			return listRules(args[1:], stdout, stderr)
+		}
+	}
+
+	opts := options{color: colorAuto, cgo: cgoAuto}
+
+	fs := flag.NewFlagSet(rsacheck.Analyzer.Name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.BoolVar(&opts.json, "json", false, "emit JSON output")
+	fs.StringVar(&opts.format, "format", "text", "output format for findings: text, short for one sorted \"file:line:col: [RULEID] message\" line per finding, json for a versioned report (see report.schema.json), flat for a JSON array of findings, or sarif for a SARIF 2.1.0 log for code scanning")
+	fs.IntVar(&opts.context, "c", -1, "display offending line with this many lines of context")
+	fs.Var(&opts.color, "color", "color text output: auto (only when writing to a terminal), always, or never")
+	fs.BoolVar(&opts.tests, "test", true, "indicates whether test files should be analyzed, too")
+	fs.StringVar(&opts.module, "module", "", "only analyze packages within the given module path")
+	fs.BoolVar(&opts.sort, "sort", false, "sort findings by file, line, column, and rule ID for reproducible output")
+	fs.StringVar(&opts.tags, "tags", "", "comma-separated list of build tags to consider satisfied during the build")
+	fs.Var(&opts.cgo, "cgo", "whether cgo is enabled when loading packages: auto (as set by CGO_ENABLED, or if a C compiler is found), on, or off, which skips files that import \"C\"")
+	fs.Var(&opts.include, "include", "only report findings in files matching the given glob, such as internal/crypto/** (can be repeated); globs are relative to the working directory, unlike -exclude, which matches the trailing elements of paths")
+	fs.Var(&opts.since, "since", "only report findings in files modified since the given duration ago (such as 24h), or time (RFC 3339, or a date such as 2006-01-02)")
+	fs.BoolVar(&opts.prodOnly, "prod-only", false, "only report findings relevant to production code: sets -test=false and -public-only, and skips generated files")
+	fs.IntVar(&opts.maxFindings, "max-findings", -1, "exit with a non-zero code if there are more than this many findings, regardless of their severity")
+	fs.StringVar(&opts.sqlite, "sqlite", "", "append the findings of the run to the given SQLite database, with the commit SHA from $RSALINT_COMMIT, $GITHUB_SHA, $CI_COMMIT_SHA, or $GIT_COMMIT")
+	fs.BoolVar(&opts.checkUpdate, "check-update", false, "check the Go module proxy for a newer release of rsalint, and print a notice if there is one")
+	fs.StringVar(&opts.cpuprofile, "cpuprofile", "", "write a CPU profile of the analysis to the given file")
+	fs.StringVar(&opts.memprofile, "memprofile", "", "write a memory profile to the given file after the analysis")
+	fs.StringVar(&opts.baseline, "baseline", "", "suppress the known findings in the given baseline file")
+	fs.BoolVar(&opts.writeBaseline, "write-baseline", false, "write the findings to the -baseline file, instead of reporting them")
+
+	rsacheck.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "%s: %s\n\n", rsacheck.Analyzer.Name, rsacheck.Analyzer.Doc)
+		fmt.Fprintf(stderr, "Usage: %s [-flag] [package]\n", rsacheck.Analyzer.Name)
+		fmt.Fprintf(stderr, "       %s selftest\n", rsacheck.Analyzer.Name)
+		fmt.Fprintf(stderr, "       %s serve -socket path\n", rsacheck.Analyzer.Name)
+		fmt.Fprintf(stderr, "       %s init-editor [-write] [vscode|golangci-lint]\n", rsacheck.Analyzer.Name)
+		fmt.Fprintf(stderr, "       %s rules [-json]\n\n", rsacheck.Analyzer.Name)
+		fmt.Fprintln(stderr, "Flags:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 1
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 1
+	}
+
+	// The -prod-only preset sets the options it combines, and restores the
+	// analyzer's -public-only flag afterwards, since analyzer flags are global.
+	if opts.prodOnly {
+		opts.tests = false
+		opts.skipGenerated = true
+
+		publicOnly := rsacheck.Analyzer.Flags.Lookup("public-only").Value
+		defer publicOnly.Set(publicOnly.String())
+		publicOnly.Set("true")
+	}
+
+	switch opts.format {
+	case "text", "short", "json", "flat", "sarif":
+	default:
+		fmt.Fprintf(stderr, "%s: unknown format %q\n", rsacheck.Analyzer.Name, opts.format)
+		return 1
+	}
+
+	stopProfiles, err := startProfiles(opts.cpuprofile, opts.memprofile)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+		return 1
+	}
+	defer func() {
+		if err := stopProfiles(); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+		}
+	}()
+
+	// The check runs concurrently with the analysis, and its notice is printed last.
+	if opts.checkUpdate {
+		notice := checkUpdate()
+		defer func() {
+			if n := <-notice; n != "" {
+				fmt.Fprintf(stderr, "%s: %s\n", rsacheck.Analyzer.Name, n)
+			}
+		}()
+	}
+
+	pkgs, err := load(opts, fs.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+		return 1
+	}
+
+	// Only packages with modified files are analyzed, but as a whole, since a
+	// package can't be type-checked without all of its files.
+	if !opts.since.IsZero() {
+		pkgs = filterModifiedPackages(pkgs, opts.since.Time)
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{rsacheck.Analyzer}, pkgs, nil)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+		return 1
+	}
+
+	results, err := applyConfig(findings(graph))
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+		return 1
+	}
+
+	results = filterIncluded(results, opts.include)
+
+	if opts.skipGenerated {
+		results = filterGenerated(results, pkgs)
+	}
+
+	if !opts.since.IsZero() {
+		results = filterModified(results, opts.since.Time)
+	}
+
+	if opts.writeBaseline {
+		if opts.baseline == "" {
+			fmt.Fprintf(stderr, "%s: -write-baseline requires -baseline\n", rsacheck.Analyzer.Name)
+			return 1
+		}
+
+		sortFindings(results)
+
+		if err := writeBaseline(opts.baseline, results); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+			return 1
+		}
+		return 0
+	}
+
+	if opts.baseline != "" {
+		results, err = applyBaseline(opts.baseline, results, stderr)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+			return 1
+		}
+	}
+
+	if opts.sqlite != "" {
+		if err := exportSQLite(opts.sqlite, results, time.Now()); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+			return 1
+		}
+	}
+
+	switch {
+	case opts.json:
+		if err := printJSON(stdout, graph, results); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+			return 1
+		}
+	case opts.format == "short":
+		sortFindings(results)
+		printShort(stdout, results)
+	case opts.format == "json":
+		sortFindings(results)
+
+		if err := printReport(stdout, results); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+			return 1
+		}
+	case opts.format == "flat":
+		sortFindings(results)
+
+		if err := printFlat(stdout, results); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+			return 1
+		}
+	case opts.format == "sarif":
+		sortFindings(results)
+
+		if err := printSARIF(stdout, results); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+			return 1
+		}
+	default:
+		if opts.sort {
+			sortFindings(results)
+		}
+		printText(stderr, results, opts.context, opts.color.enabled(stderr))
+	}
+
+	var errs int
+	for act := range graph.All() {
+		if act.Err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", act.Analyzer.Name, act.Err)
+			errs++
+		}
+	}
+
+	if errs > 0 {
+		return 1
+	}
+
+	if opts.maxFindings >= 0 && len(results) > opts.maxFindings {
+		fmt.Fprintf(stderr, "%s: %d findings exceed the maximum of %d\n", rsacheck.Analyzer.Name, len(results), opts.maxFindings)
+		return 3
+	}
+
+	for _, f := range results {
+		if f.severity == rsacheck.SeverityError {
+			return 3
+		}
+	}
+	return 0
 }
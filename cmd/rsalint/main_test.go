@@ -0,0 +1,1184 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/picatz/rsalint/rsacheck"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestModuleFilter(t *testing.T) {
+	chdir(t, filepath.Join("testdata", "workspace"))
+
+	// Workspace mode only allows -mod=readonly or -mod=vendor.
+	t.Setenv("GOFLAGS", "")
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-module", "example.com/b", "./a/...", "./b/..."}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	if !strings.Contains(stderr.String(), filepath.Join("b", "main.go")) {
+		t.Errorf("expected finding in module example.com/b, got:\n%s", stderr.String())
+	}
+
+	if strings.Contains(stderr.String(), filepath.Join("a", "main.go")) {
+		t.Errorf("unexpected finding in module example.com/a, got:\n%s", stderr.String())
+	}
+}
+
+func TestSort(t *testing.T) {
+	var runs []string
+
+	for range 3 {
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{"-sort", "../../rsacheck/testdata/src/vulnerable"}, &stdout, &stderr)
+		if code != 3 {
+			t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+		}
+
+		runs = append(runs, stderr.String())
+	}
+
+	for _, output := range runs[1:] {
+		if output != runs[0] {
+			t.Fatalf("expected the same output across runs, got:\n%s\nand:\n%s", runs[0], output)
+		}
+	}
+
+	// Findings at the same position are ordered by their rule ID.
+	want := []string{
+		"math/rand is not cryptographically secure; use crypto/rand.Reader", // RSA001
+		"use 2048 bits or greater",                                          // RSA002
+		"for 1024 bits 3 is the max number of primes to use",                // RSA003
+		"warning: use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey", // RSA004
+	}
+
+	lines := strings.Split(runs[0], "\n")
+	for i, message := range want {
+		if !strings.HasSuffix(lines[i], "main.go:14:46: "+message) {
+			t.Errorf("line %d: expected %q, got %q", i, message, lines[i])
+		}
+	}
+}
+
+func TestConfig(t *testing.T) {
+	chdir(t, filepath.Join("testdata", "config"))
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-sort", "./..."}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	// The root configuration disables deprecated findings, and the relaxed
+	// subdirectory disables weak keys, but re-enables RSA004 (deprecated).
+	want := []string{
+		"main.go:12:46: use 2048 bits or greater",
+		filepath.Join("relaxed", "relaxed.go") + ":9:46: warning: use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey",
+	}
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d findings, got:\n%s", len(want), stderr.String())
+	}
+
+	for i, suffix := range want {
+		if !strings.HasSuffix(lines[i], suffix) {
+			t.Errorf("line %d: expected suffix %q, got %q", i, suffix, lines[i])
+		}
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	chdir(t, filepath.Join("testdata", "severity"))
+
+	var stdout, stderr bytes.Buffer
+
+	// Findings that aren't errors don't result in a non-zero exit code.
+	code := run([]string{"-sort", "."}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	want := []string{
+		"main.go:10:46: warning: use 2048 bits or greater",
+		"main.go:10:46: info: use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey",
+	}
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d findings, got:\n%s", len(want), stderr.String())
+	}
+
+	for i, suffix := range want {
+		if !strings.HasSuffix(lines[i], suffix) {
+			t.Errorf("line %d: expected suffix %q, got %q", i, suffix, lines[i])
+		}
+	}
+
+	// The strict subdirectory upgrades RSA005 (weak-encryption) back to an error.
+	stderr.Reset()
+
+	code = run([]string{"./strict"}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	if !strings.HasSuffix(strings.TrimSpace(stderr.String()), ": use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15") {
+		t.Errorf("expected error finding without a severity prefix, got:\n%s", stderr.String())
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	pkg := "../../rsacheck/testdata/src/vulnerable"
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-baseline", path, "-write-baseline", pkg}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	// Known findings are suppressed.
+	code = run([]string{"-baseline", path, pkg}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	// Changing the rule set invalidates the baseline, so findings reappear.
+	rulesVersion++
+	t.Cleanup(func() { rulesVersion-- })
+
+	stderr.Reset()
+
+	code = run([]string{"-baseline", path, pkg}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	if !strings.Contains(stderr.String(), "ignoring baseline") {
+		t.Errorf("expected a warning about the stale baseline, got:\n%s", stderr.String())
+	}
+
+	if !strings.Contains(stderr.String(), "use 2048 bits or greater") {
+		t.Errorf("expected findings to be reported, got:\n%s", stderr.String())
+	}
+}
+
+func TestInclude(t *testing.T) {
+	chdir(t, filepath.Join("testdata", "config"))
+
+	var stdout, stderr bytes.Buffer
+
+	// The only finding in the relaxed directory, of the deprecated RSA004, is a
+	// warning, which doesn't fail the run.
+	code := run([]string{"-include", "relaxed/**", "./..."}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], filepath.Join("relaxed", "relaxed.go")) {
+		t.Errorf("expected only findings in the relaxed directory, got:\n%s", stderr.String())
+	}
+}
+
+func TestJSONInclude(t *testing.T) {
+	chdir(t, filepath.Join("testdata", "config"))
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-json", "-include", "relaxed/**", "./..."}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	var tree map[string]map[string][]struct {
+		Posn string `json:"posn"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &tree); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout.String())
+	}
+
+	var posns []string
+	for _, analyzers := range tree {
+		for _, diag := range analyzers["rsalint"] {
+			posns = append(posns, diag.Posn)
+		}
+	}
+
+	if len(posns) != 1 || !strings.Contains(posns[0], filepath.Join("relaxed", "relaxed.go")) {
+		t.Errorf("expected only findings in the relaxed directory, got:\n%s", stdout.String())
+	}
+}
+
+func TestShortFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-format=short", "../../rsacheck/testdata/src/vulnerable"}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	golden := filepath.Join("testdata", "short.golden")
+
+	if *update {
+		if err := os.WriteFile(golden, stdout.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stdout.String() != string(want) {
+		t.Errorf("output doesn't match %s (run with -update to update it):\ngot:\n%s\nwant:\n%s", golden, stdout.String(), want)
+	}
+}
+
+func TestFlatFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-format=flat", "../../rsacheck/testdata/src/vulnerable"}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	golden := filepath.Join("testdata", "flat.golden")
+
+	if *update {
+		if err := os.WriteFile(golden, stdout.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stdout.String() != string(want) {
+		t.Errorf("output doesn't match %s (run with -update to update it):\ngot:\n%s\nwant:\n%s", golden, stdout.String(), want)
+	}
+}
+
+func TestRuleSeverity(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	// PKCS #1 v1.5 signatures are only an advisory.
+	code := run([]string{"-format=flat", "../../rsacheck/testdata/src/sign-pkcs1v15"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0 for info findings, got %d: %s", code, stderr.String())
+	}
+
+	var findings []flatFinding
+	if err := json.Unmarshal(stdout.Bytes(), &findings); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(findings) != 1 || findings[0].RuleID != "RSA029" || findings[0].Severity != string(rsacheck.SeverityInfo) {
+		t.Errorf("expected a single RSA029 finding with an info severity, got %+v", findings)
+	}
+}
+
+func TestServe(t *testing.T) {
+	l, err := net.Listen("unix", filepath.Join(t.TempDir(), "rsalint.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	go newServer().serve(l)
+
+	conn, err := net.Dial("unix", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	dir, err := filepath.Abs(filepath.Join("testdata", "config"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The overlay replaces the file on disk, such as an unsaved file in an editor.
+	overlay := `package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func main() {
+	rsa.GenerateKey(rand.Reader, 512)
+}
+`
+
+	requests := []request{
+		{Dir: dir, Patterns: []string{"."}, Overlay: map[string]string{filepath.Join(dir, "main.go"): overlay}},
+		{Dir: dir, Patterns: []string{"."}},
+	}
+
+	enc := json.NewEncoder(conn)
+	scanner := bufio.NewScanner(conn)
+
+	var responses []response
+	for _, req := range requests {
+		if err := enc.Encode(req); err != nil {
+			t.Fatal(err)
+		}
+
+		if !scanner.Scan() {
+			t.Fatalf("expected a response: %v", scanner.Err())
+		}
+
+		var resp response
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+
+		if resp.Error != "" {
+			t.Fatalf("unexpected error: %s", resp.Error)
+		}
+
+		responses = append(responses, resp)
+	}
+
+	if got := responses[0].Findings; len(got) != 1 || got[0].RuleID != "RSA002" || got[0].Line != 9 || got[0].Package != "example.com/config" {
+		t.Errorf("expected a single RSA002 finding on line 9 of the overlay in example.com/config, got %+v", got)
+	}
+
+	rules := map[string]bool{}
+	for _, f := range responses[1].Findings {
+		rules[f.RuleID] = true
+	}
+
+	if !rules["RSA002"] || !rules["RSA004"] {
+		t.Errorf("expected RSA002 and RSA004 findings for the file on disk, got %+v", responses[1].Findings)
+	}
+}
+
+func TestColor(t *testing.T) {
+	pkg := "../../rsacheck/testdata/src/vulnerable"
+
+	// Output that's piped is never colored by default.
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- data
+	}()
+
+	var stdout bytes.Buffer
+
+	code := run([]string{pkg}, &stdout, w)
+	w.Close()
+
+	piped := <-output
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, piped)
+	}
+
+	if bytes.Contains(piped, []byte("\x1b[")) {
+		t.Errorf("expected no escape sequences in piped output, got:\n%q", piped)
+	}
+
+	var stderr bytes.Buffer
+
+	run([]string{"-color=always", pkg}, &stdout, &stderr)
+	if !strings.Contains(stderr.String(), "\x1b[") {
+		t.Errorf("expected escape sequences with -color=always, got:\n%q", stderr.String())
+	}
+}
+
+func TestMaxFindings(t *testing.T) {
+	chdir(t, filepath.Join("testdata", "severity"))
+
+	var stdout, stderr bytes.Buffer
+
+	// The two findings are a warning and info, which otherwise don't fail the build.
+	code := run([]string{"-max-findings", "1", "."}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	if !strings.Contains(stderr.String(), "2 findings exceed the maximum of 1") {
+		t.Errorf("expected the limit to be reported, got:\n%s", stderr.String())
+	}
+
+	stderr.Reset()
+
+	code = run([]string{"-max-findings", "2", "."}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+}
+
+func TestJSONPackage(t *testing.T) {
+	chdir(t, filepath.Join("testdata", "severity"))
+
+	var stdout, stderr bytes.Buffer
+
+	// The strict package isn't relaxed by the config file, and has errors.
+	code := run([]string{"-json", "./..."}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	var tree map[string]map[string][]struct {
+		Package string `json:"package"`
+		Posn    string `json:"posn"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &tree); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout.String())
+	}
+
+	want := map[string]string{
+		"main.go":   "example.com/severity",
+		"strict.go": "example.com/severity/strict",
+	}
+
+	got := map[string]string{}
+	for _, analyzers := range tree {
+		for _, diag := range analyzers["rsalint"] {
+			file, _, _ := strings.Cut(filepath.Base(diag.Posn), ":")
+			got[file] = diag.Package
+		}
+	}
+
+	for file, pkg := range want {
+		if got[file] != pkg {
+			t.Errorf("expected findings in %s to have package %q, got %q", file, pkg, got[file])
+		}
+	}
+}
+
+func TestReportSchema(t *testing.T) {
+	got, err := json.MarshalIndent(reportSchema(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	if *update {
+		if err := os.WriteFile("report.schema.json", got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile("report.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("report.schema.json doesn't match the report types (run with -update to update it)")
+	}
+}
+
+func TestJSONReport(t *testing.T) {
+	data, err := os.ReadFile("report.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-format=json", "../../rsacheck/testdata/src/vulnerable"}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	var r any
+	if err := json.Unmarshal(stdout.Bytes(), &r); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout.String())
+	}
+
+	if err := validate(schema, r, "$"); err != nil {
+		t.Fatalf("output doesn't conform to the schema: %v\n%s", err, stdout.String())
+	}
+
+	var got report
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.SchemaVersion != reportSchemaVersion || len(got.Findings) == 0 {
+		t.Fatalf("expected findings with schema version %d, got %+v", reportSchemaVersion, got)
+	}
+
+	first := got.Findings[0]
+	if first.RuleID != "RSA001" || first.Category != "weak-random" || first.Confidence != "high" || first.Severity != "error" {
+		t.Errorf("expected rule metadata for the first finding, got %+v", first)
+	}
+	if !strings.HasSuffix(first.Package, "testdata/src/vulnerable") {
+		t.Errorf("expected the package path of the fixture, got %q", first.Package)
+	}
+}
+
+// validate validates the value against the subset of JSON Schema generated by
+// reportSchema: types, properties, required properties, items, and enums.
+func validate(schema map[string]any, value any, path string) error {
+	switch schema["type"] {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an object, got %T", path, value)
+		}
+
+		properties, _ := schema["properties"].(map[string]any)
+
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := obj[name.(string)]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+
+		for name, v := range obj {
+			prop, ok := properties[name].(map[string]any)
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s: unexpected property %q", path, name)
+				}
+				continue
+			}
+
+			if err := validate(prop, v, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected an array, got %T", path, value)
+		}
+
+		items, _ := schema["items"].(map[string]any)
+		for i, v := range arr {
+			if err := validate(items, v, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string, got %T", path, value)
+		}
+
+		if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, any(s)) {
+			return fmt.Errorf("%s: %q is not one of %v", path, s, enum)
+		}
+	case "integer":
+		n, ok := value.(float64)
+		if !ok || n != float64(int64(n)) {
+			return fmt.Errorf("%s: expected an integer, got %v", path, value)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported schema type %v", path, schema["type"])
+	}
+
+	return nil
+}
+
+func TestSARIF(t *testing.T) {
+	chdir(t, filepath.Join("testdata", "severity"))
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-format=sarif", "."}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(stdout.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout.String())
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("expected a SARIF 2.1.0 log with one run, got %+v", log)
+	}
+
+	r := log.Runs[0]
+	if len(r.Tool.Driver.Rules) != len(rsacheck.Rules) {
+		t.Errorf("expected %d rules, got %d", len(rsacheck.Rules), len(r.Tool.Driver.Rules))
+	}
+
+	if tags := r.Tool.Driver.Rules[0].Properties.Tags; !slices.Contains(tags, "external/cwe/cwe-338") {
+		t.Errorf("expected the CWE of RSA001 in its tags, got %v", tags)
+	}
+
+	want := []struct {
+		ruleID, level string
+	}{
+		{"RSA002", "warning"},
+		{"RSA004", "note"},
+	}
+
+	if len(r.Results) != len(want) {
+		t.Fatalf("expected %d results, got %+v", len(want), r.Results)
+	}
+
+	for i, w := range want {
+		result := r.Results[i]
+		if result.RuleID != w.ruleID || result.Level != w.level {
+			t.Errorf("result %d: expected %s with level %s, got %s with level %s", i, w.ruleID, w.level, result.RuleID, result.Level)
+		}
+
+		if rule := r.Tool.Driver.Rules[result.RuleIndex]; rule.ID != result.RuleID {
+			t.Errorf("result %d: rule index %d refers to %s, not %s", i, result.RuleIndex, rule.ID, result.RuleID)
+		}
+
+		loc := result.Locations[0].PhysicalLocation
+		if loc.ArtifactLocation.URI != "main.go" || loc.ArtifactLocation.URIBaseID != "%SRCROOT%" || loc.Region.StartLine != 10 || loc.Region.StartColumn != 46 {
+			t.Errorf("result %d: expected main.go:10:46 relative to %%SRCROOT%%, got %+v", i, loc)
+		}
+	}
+}
+
+func TestProdOnly(t *testing.T) {
+	chdir(t, filepath.Join("testdata", "prodonly"))
+
+	// Generated files are skipped by the analyzer by default, so they're linted
+	// to check that -prod-only skips them too.
+	lintGenerated := rsacheck.Analyzer.Flags.Lookup("lint-generated").Value
+	lintGenerated.Set("true")
+	t.Cleanup(func() { lintGenerated.Set("false") })
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-format=short", "."}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	for _, file := range []string{"main.go", "keys_gen.go", "main_test.go"} {
+		if !strings.Contains(stdout.String(), file+":") {
+			t.Errorf("expected findings in %s without -prod-only, got:\n%s", file, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+
+	// Only the finding in the main function, the entry point of the command, is kept.
+	code = run([]string{"-format=short", "-prod-only", "."}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	want := "main.go:9:17: [RSA002] use 2048 bits or greater\n"
+	if stdout.String() != want {
+		t.Errorf("expected only the production finding:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+
+	if f := rsacheck.Analyzer.Flags.Lookup("public-only"); f.Value.String() != "false" {
+		t.Errorf("expected -public-only to be restored, got %s", f.Value)
+	}
+}
+
+func TestMinSeverity(t *testing.T) {
+	minSeverity := rsacheck.Analyzer.Flags.Lookup("min-severity").Value
+	t.Cleanup(func() { minSeverity.Set("info") })
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-format=short", "-min-severity=error", "../../rsacheck/testdata/src/vulnerable"}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	if !strings.Contains(stdout.String(), "[RSA002]") {
+		t.Errorf("expected the weak key size to be reported, got:\n%s", stdout.String())
+	}
+
+	// Deprecated rsa.GenerateMultiPrimeKey calls are warnings, and PKCS #1 v1.5
+	// signatures are advisories.
+	for _, id := range []string{"RSA004", "RSA029"} {
+		if strings.Contains(stdout.String(), "["+id+"]") {
+			t.Errorf("expected %s findings to be filtered out, got:\n%s", id, stdout.String())
+		}
+	}
+}
+
+func TestInitEditor(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"init-editor", "golangci-lint"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	for _, want := range []string{
+		"# .custom-gcl.yml\n",
+		"import: " + golangciPluginImport + "\n",
+		"# .golangci.yml\n",
+		"custom:\n    " + golangciPlugin + ":\n      type: module\n",
+		"enable:\n    - " + golangciPlugin + "\n",
+	} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, stdout.String())
+		}
+	}
+
+	// Files are written to the working directory, and existing files aren't overwritten.
+	chdir(t, t.TempDir())
+
+	stdout.Reset()
+
+	code = run([]string{"init-editor", "-write"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	data, err := os.ReadFile(filepath.Join(".vscode", "settings.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var settings map[string]any
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("invalid settings.json: %v", err)
+	}
+
+	if flags, _ := settings["go.vetFlags"].([]any); len(flags) != 1 || !strings.HasPrefix(flags[0].(string), "-vettool=") {
+		t.Errorf("expected rsalint to be used as the vet tool, got %v", settings)
+	}
+
+	stderr.Reset()
+
+	code = run([]string{"init-editor", "-write"}, &stdout, &stderr)
+	if code != 1 || !strings.Contains(stderr.String(), "already exists") {
+		t.Errorf("expected existing files not to be overwritten, got exit code %d: %s", code, stderr.String())
+	}
+}
+
+func TestSince(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod": "module example.com/since\n\ngo 1.23.0\n",
+		"keys/old.go": `package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func OldKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024)
+}
+`,
+		"keys/new.go": `package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func NewKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 512)
+}
+`,
+		"legacy/legacy.go": `package legacy
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func LegacyKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024)
+}
+`,
+	}
+
+	old := time.Now().Add(-48 * time.Hour)
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if name != "keys/new.go" {
+			if err := os.Chtimes(path, old, old); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	chdir(t, dir)
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-format=short", "-since=24h", "./..."}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	// The package of the modified file is analyzed as a whole, but only findings in
+	// the modified file are reported.
+	want := "keys/new.go:9:24: [RSA002] use 2048 bits or greater\n"
+	if stdout.String() != want {
+		t.Errorf("expected only findings in the modified file:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+
+	stdout.Reset()
+
+	code = run([]string{"-format=short", "-since=" + old.Add(-time.Hour).Format(time.RFC3339), "./..."}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	if n := strings.Count(stdout.String(), "\n"); n != 3 {
+		t.Errorf("expected findings in all 3 files, got:\n%s", stdout.String())
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	dir := t.TempDir()
+
+	cpuprofile := filepath.Join(dir, "cpu.pprof")
+	memprofile := filepath.Join(dir, "mem.pprof")
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-cpuprofile", cpuprofile, "-memprofile", memprofile, "../../rsacheck/testdata/src/vulnerable"}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	for _, path := range []string{cpuprofile, memprofile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Size() == 0 {
+			t.Errorf("expected %s to be non-empty", filepath.Base(path))
+		}
+	}
+
+	// Profiles are also written when the run fails.
+	os.Remove(memprofile)
+
+	code = run([]string{"-memprofile", memprofile, "./does-not-exist"}, &stdout, &stderr)
+	if code != 1 {
+		t.Fatalf("expected exit code 1, got %d: %s", code, stderr.String())
+	}
+
+	if info, err := os.Stat(memprofile); err != nil || info.Size() == 0 {
+		t.Errorf("expected a non-empty memory profile after an error, got %v", err)
+	}
+}
+
+func TestSQLite(t *testing.T) {
+	chdir(t, filepath.Join("testdata", "severity"))
+	t.Setenv("RSALINT_COMMIT", "0123abc")
+
+	db := filepath.Join(t.TempDir(), "findings.db")
+
+	for range 2 {
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{"-sqlite", db, "."}, &stdout, &stderr)
+		if code != 0 {
+			t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+		}
+	}
+
+	conn, err := sql.Open("sqlite", db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	rows, err := conn.Query(`SELECT runs.id, commit_sha, rule_id, file, length(fingerprint) FROM findings JOIN runs ON runs.id = run_id ORDER BY runs.id, line`)
+	if err != nil {
+		t.Fatalf("querying findings: %v", err)
+	}
+	defer rows.Close()
+
+	var out strings.Builder
+	for rows.Next() {
+		var (
+			runID, fingerprintLen int
+			commit, ruleID, file  string
+		)
+		if err := rows.Scan(&runID, &commit, &ruleID, &file, &fingerprintLen); err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprintf(&out, "%d|%s|%s|%s|%d\n", runID, commit, ruleID, file, fingerprintLen)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `1|0123abc|RSA002|main.go|32
+1|0123abc|RSA004|main.go|32
+2|0123abc|RSA002|main.go|32
+2|0123abc|RSA004|main.go|32
+`
+	if out.String() != want {
+		t.Errorf("expected findings of both runs:\n%s\ngot:\n%s", want, out.String())
+	}
+}
+
+func TestCgo(t *testing.T) {
+	chdir(t, filepath.Join("testdata", "cgo"))
+
+	var stdout, stderr bytes.Buffer
+
+	// Files that import "C" are excluded with cgo disabled, so the package loads
+	// without the C library, and the pure Go files are still analyzed.
+	code := run([]string{"-cgo=off", "-format=short", "."}, &stdout, &stderr)
+	if code != 3 {
+		t.Fatalf("expected exit code 3, got %d: %s", code, stderr.String())
+	}
+
+	want := "keys.go:9:24: [RSA002] use 2048 bits or greater\n"
+	if stdout.String() != want {
+		t.Errorf("expected the finding in the pure Go file:\ngot:\n%s\nwant:\n%s", stdout.String(), want)
+	}
+
+	stderr.Reset()
+
+	code = run([]string{"-cgo=on", "."}, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("expected exit code 1 with cgo enabled, got %d: %s", code, stderr.String())
+	}
+
+	code = run([]string{"-cgo=maybe", "."}, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("expected exit code 1 for an invalid -cgo value, got %d", code)
+	}
+}
+
+// roundTripFunc is an [http.RoundTripper] that stubs responses.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestCheckUpdate(t *testing.T) {
+	var requested string
+
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = req.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"Version":"v1.2.3","Time":"2024-06-01T00:00:00Z"}`)),
+		}, nil
+	})}
+
+	latest, err := latestVersion(context.Background(), client, "https://proxy.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if latest != "v1.2.3" {
+		t.Errorf("expected v1.2.3, got %q", latest)
+	}
+
+	if want := "https://proxy.example.com/github.com/picatz/rsalint/@latest"; requested != want {
+		t.Errorf("expected request to %s, got %s", want, requested)
+	}
+
+	for _, tt := range []struct {
+		current string
+		notice  bool
+	}{
+		{"v1.0.0", true},
+		{"v1.2.3", false},
+		{"v1.3.0", false},
+		{"(devel)", false},
+	} {
+		if notice := updateNotice(tt.current, latest); (notice != "") != tt.notice {
+			t.Errorf("updateNotice(%q, %q) = %q, expected a notice: %v", tt.current, latest, notice, tt.notice)
+		}
+	}
+
+	// Failures, such as without network access, don't fail the run, and print nothing.
+	updateClient = &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("network is unreachable")
+	})}
+	t.Cleanup(func() { updateClient = http.DefaultClient })
+
+	if notice := <-checkUpdate(); notice != "" {
+		t.Errorf("expected no notice when the check fails, got %q", notice)
+	}
+
+	chdir(t, filepath.Join("testdata", "severity"))
+
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-check-update", "."}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	if strings.Contains(stderr.String(), "newer version") {
+		t.Errorf("expected no notice, got:\n%s", stderr.String())
+	}
+}
+
+func TestVetTool(t *testing.T) {
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	tool := filepath.Join(t.TempDir(), "rsalint")
+	if out, err := exec.Command(goCmd, "build", "-o", tool, ".").CombinedOutput(); err != nil {
+		t.Fatalf("building rsalint: %v\n%s", err, out)
+	}
+
+	tests := []struct {
+		flags []string
+		want  []string
+	}{
+		{
+			want: []string{"keys.go:13:24: use 2048 bits or greater"},
+		},
+		{
+			flags: []string{"-min-bits=4096"},
+			want:  []string{"keys.go:9:24: use 4096 bits or greater", "keys.go:13:24: use 4096 bits or greater"},
+		},
+	}
+
+	for _, test := range tests {
+		args := append([]string{"vet", "-vettool=" + tool}, test.flags...)
+		cmd := exec.Command(goCmd, append(args, ".")...)
+		cmd.Dir = filepath.Join("testdata", "vettool")
+
+		// Findings make "go vet" fail, like its own analyzers.
+		out, err := cmd.CombinedOutput()
+		if err == nil {
+			t.Errorf("go vet %v: expected an error for the weak key:\n%s", test.flags, out)
+		}
+
+		for _, want := range test.want {
+			if !strings.Contains(string(out), want) {
+				t.Errorf("go vet %v: expected %q in the output:\n%s", test.flags, want, out)
+			}
+		}
+	}
+}
+
+func TestRules(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"rules", "--json"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	var catalog []ruleInfo
+	if err := json.Unmarshal(stdout.Bytes(), &catalog); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout.String())
+	}
+
+	if len(catalog) != len(rsacheck.Rules) {
+		t.Fatalf("expected %d rules, got %d", len(rsacheck.Rules), len(catalog))
+	}
+
+	for i, rule := range catalog {
+		if rule.ID != rsacheck.Rules[i].ID {
+			t.Errorf("rule %d: expected %s, got %s", i, rsacheck.Rules[i].ID, rule.ID)
+		}
+
+		if rule.Category == "" || rule.Severity == "" || rule.Confidence == "" || rule.CWE == "CWE-0" || len(rule.Messages) == 0 || len(rule.References) == 0 || rule.Remediation == "" {
+			t.Errorf("rule %s has empty fields: %+v", rule.ID, rule)
+		}
+
+		for _, message := range rule.Messages {
+			if message == "" {
+				t.Errorf("rule %s has an empty message template", rule.ID)
+			}
+		}
+	}
+
+	stdout.Reset()
+
+	code = run([]string{"rules"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+
+	for _, rule := range rsacheck.Rules {
+		if !strings.Contains(stdout.String(), "\n"+rule.ID+" ") {
+			t.Errorf("expected a row for %s, got:\n%s", rule.ID, stdout.String())
+		}
+	}
+}
+
+func TestSelftest(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"selftest"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d:\n%s%s", code, stdout.String(), stderr.String())
+	}
+
+	for _, fixture := range []string{"vulnerable", "not-vulnerable"} {
+		if !strings.Contains(stdout.String(), "ok  \t"+fixture+"\n") {
+			t.Errorf("expected fixture %q to pass, got:\n%s", fixture, stdout.String())
+		}
+	}
+}
+
+// chdir changes the working directory for the duration of the test.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		os.Chdir(wd)
+	})
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// startProfiles starts writing a CPU profile to the given file, if set, and returns a
+// function that stops it, and writes a heap profile to the other file, if set. The
+// function must be called on every exit, so that the profiles are flushed.
+func startProfiles(cpuprofile, memprofile string) (stop func() error, err error) {
+	var cpu *os.File
+
+	if cpuprofile != "" {
+		cpu, err = os.Create(cpuprofile)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := pprof.StartCPUProfile(cpu); err != nil {
+			cpu.Close()
+			return nil, err
+		}
+	}
+
+	stop = func() error {
+		var errs []error
+
+		if cpu != nil {
+			pprof.StopCPUProfile()
+			errs = append(errs, cpu.Close())
+		}
+
+		if memprofile != "" {
+			errs = append(errs, writeHeapProfile(memprofile))
+		}
+
+		return errors.Join(errs...)
+	}
+
+	return stop, nil
+}
+
+// writeHeapProfile writes a heap profile, with up-to-date statistics, to the given file.
+func writeHeapProfile(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+
+	runtime.GC()
+
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/picatz/rsalint/rsacheck"
+)
+
+// reportSchemaVersion is the version of the -format=json output. It's incremented
+// whenever the output changes in a way that isn't backwards compatible, such as
+// removing or renaming a field. Adding fields doesn't change the version.
+const reportSchemaVersion = 1
+
+// report is the -format=json output, described by the report.schema.json document.
+type report struct {
+	SchemaVersion int             `json:"schema_version" jsonschema:"Version of the schema the report conforms to."`
+	Findings      []reportFinding `json:"findings" jsonschema:"Findings, sorted by file, line, column, and rule ID."`
+}
+
+// reportFinding is a finding in the -format=json output.
+type reportFinding struct {
+	RuleID      string          `json:"rule_id" jsonschema:"ID of the rule that reported the finding, such as RSA002."`
+	Category    string          `json:"category" jsonschema:"Category of the rule, such as weak-key."`
+	Severity    string          `json:"severity" jsonschema:"Severity of the finding." enum:"error,warning,info"`
+	Confidence  string          `json:"confidence" jsonschema:"How likely the finding is a real issue." enum:"high,medium,low"`
+	Package     string          `json:"package" jsonschema:"Import path of the package the finding was reported in."`
+	File        string          `json:"file" jsonschema:"Path of the file, relative to the working directory when possible."`
+	Line        int             `json:"line" jsonschema:"Line of the finding, starting at 1."`
+	Column      int             `json:"column" jsonschema:"Column of the finding in bytes, starting at 1."`
+	Message     string          `json:"message" jsonschema:"Description of the finding."`
+	Fingerprint string          `json:"fingerprint" jsonschema:"Stable identifier of the finding, which doesn't change when unrelated lines are added or removed."`
+	Related     []reportRelated `json:"related,omitempty" jsonschema:"Secondary positions of the finding, such as where a weak key is used."`
+}
+
+// reportRelated is a secondary position of a finding in the -format=json output.
+type reportRelated struct {
+	File    string `json:"file" jsonschema:"Path of the file, relative to the working directory when possible."`
+	Line    int    `json:"line" jsonschema:"Line of the position, starting at 1."`
+	Column  int    `json:"column" jsonschema:"Column of the position in bytes, starting at 1."`
+	Message string `json:"message" jsonschema:"Description of the position."`
+}
+
+// printReport prints the findings as a JSON report.
+func printReport(w io.Writer, fs []finding) error {
+	wd, _ := os.Getwd()
+
+	r := report{SchemaVersion: reportSchemaVersion, Findings: []reportFinding{}}
+
+	for _, f := range fs {
+		rf := reportFinding{
+			RuleID:      f.ruleID,
+			Severity:    string(f.severity),
+			Package:     f.pkgPath,
+			File:        relativePath(wd, f.posn.Filename),
+			Line:        f.posn.Line,
+			Column:      f.posn.Column,
+			Message:     f.message,
+			Fingerprint: fingerprint(wd, f),
+		}
+
+		if rule, ok := rsacheck.LookupRule(f.ruleID); ok {
+			rf.Category = rule.Category
+			rf.Confidence = rule.Confidence
+		}
+
+		for _, rel := range f.related {
+			rf.Related = append(rf.Related, reportRelated{
+				File:    relativePath(wd, rel.posn.Filename),
+				Line:    rel.posn.Line,
+				Column:  rel.posn.Column,
+				Message: rel.message,
+			})
+		}
+
+		r.Findings = append(r.Findings, rf)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// relativePath returns the path relative to the working directory when possible,
+// using forward slashes.
+func relativePath(wd, path string) string {
+	if rel, err := filepath.Rel(wd, path); err == nil && wd != "" {
+		path = rel
+	}
+	return filepath.ToSlash(path)
+}
+
+// fingerprint returns a stable identifier of the finding, computed from its rule,
+// file, message, and the contents of its line, instead of the line number, so that
+// it doesn't change when unrelated lines are added or removed.
+func fingerprint(wd string, f finding) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s", f.ruleID, relativePath(wd, f.posn.Filename), f.message, sourceLine(f.posn.Filename, f.posn.Line))
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// sourceLine returns the given line of the file, without surrounding whitespace.
+func sourceLine(filename string, line int) string {
+	file, err := os.Open(filename)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		if n == line {
+			return strings.TrimSpace(scanner.Text())
+		}
+	}
+	return ""
+}
+
+// reportSchema returns the JSON Schema document describing the report, generated
+// from the report's types, their JSON field names, and their jsonschema and enum
+// struct tags. Fields without omitempty are required.
+func reportSchema() map[string]any {
+	schema := typeSchema(reflect.TypeFor[report]())
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = "https://github.com/picatz/rsalint/cmd/rsalint/report.schema.json"
+	schema["title"] = "rsalint report"
+	return schema
+}
+
+// typeSchema returns the JSON Schema of the given type.
+func typeSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Int:
+		return map[string]any{"type": "integer"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]any{}
+		required := []string{}
+
+		for i := range t.NumField() {
+			field := t.Field(i)
+
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+			prop := typeSchema(field.Type)
+			if desc := field.Tag.Get("jsonschema"); desc != "" {
+				prop["description"] = desc
+			}
+			if enum := field.Tag.Get("enum"); enum != "" {
+				prop["enum"] = strings.Split(enum, ",")
+			}
+
+			properties[name] = prop
+			if opts != "omitempty" {
+				required = append(required, name)
+			}
+		}
+
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	}
+
+	panic(fmt.Sprintf("rsalint: unsupported type %v in report schema", t))
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/picatz/rsalint/rsacheck"
+)
+
+// ruleInfo is a rule in the output of the "rules" subcommand.
+type ruleInfo struct {
+	ID          string   `json:"id"`
+	Category    string   `json:"category"`
+	Severity    string   `json:"severity"`
+	Confidence  string   `json:"confidence"`
+	CWE         string   `json:"cwe"`
+	Messages    []string `json:"messages"`
+	References  []string `json:"references"`
+	Remediation string   `json:"remediation"`
+}
+
+// rulesCatalog returns the rules checked by the analyzer, with their default severity,
+// which can be overridden in a .rsalint.yml file.
+func rulesCatalog() []ruleInfo {
+	var catalog []ruleInfo
+	for _, rule := range rsacheck.Rules {
+		catalog = append(catalog, ruleInfo{
+			ID:          rule.ID,
+			Category:    rule.Category,
+			Severity:    string(rule.Severity),
+			Confidence:  rule.Confidence,
+			CWE:         cweID(rule),
+			Messages:    rule.Messages(),
+			References:  rule.References,
+			Remediation: rule.Remediation,
+		})
+	}
+	return catalog
+}
+
+// cweID returns the identifier of the rule's CWE entry, such as CWE-326.
+func cweID(rule *rsacheck.Rule) string {
+	return fmt.Sprintf("CWE-%d", rule.CWE)
+}
+
+// listRules runs the "rules" subcommand, which prints the catalog of rules checked by
+// the analyzer, as a table, or as JSON with -json, for tooling and documentation.
+func listRules(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet(rsacheck.Analyzer.Name+" rules", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "print the rules as JSON, with their message templates, references, and remediation")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 1
+	}
+
+	catalog := rulesCatalog()
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(catalog); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+			return 1
+		}
+		return 0
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tCATEGORY\tSEVERITY\tCONFIDENCE\tCWE\tMESSAGES")
+	for _, rule := range catalog {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", rule.ID, rule.Category, rule.Severity, rule.Confidence, rule.CWE, rule.Messages[0])
+		for _, message := range rule.Messages[1:] {
+			fmt.Fprintf(tw, "\t\t\t\t\t%s\n", message)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/picatz/rsalint/rsacheck"
+)
+
+// SARIF 2.1.0 constants used in the -format=sarif output.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifSrcRoot = "%SRCROOT%"
+)
+
+// sarifLog is the -format=sarif output, a SARIF 2.1.0 log with a single run.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID         string              `json:"id"`
+	HelpURI    string              `json:"helpUri,omitempty"`
+	Properties sarifRuleProperties `json:"properties"`
+}
+
+type sarifRuleProperties struct {
+	Category   string   `json:"category"`
+	Confidence string   `json:"confidence"`
+	Tags       []string `json:"tags"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	RelatedLocations    []sarifLocation   `json:"relatedLocations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	ID               int                   `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+// sarifLevels maps the severities of findings to SARIF result levels.
+var sarifLevels = map[rsacheck.Severity]string{
+	rsacheck.SeverityError:   "error",
+	rsacheck.SeverityWarning: "warning",
+	rsacheck.SeverityInfo:    "note",
+}
+
+// printSARIF prints the findings as a SARIF 2.1.0 log, for code scanning tools and
+// security dashboards. All of the analyzer's rules are listed, so that each result's
+// ruleId and ruleIndex refer to a stable rule, even if it has no findings.
+func printSARIF(w io.Writer, fs []finding) error {
+	wd, _ := os.Getwd()
+
+	driver := sarifDriver{
+		Name:           rsacheck.Analyzer.Name,
+		InformationURI: "https://github.com/picatz/rsalint",
+		Rules:          []sarifRule{},
+	}
+
+	index := map[string]int{}
+	for i, rule := range rsacheck.Rules {
+		index[rule.ID] = i
+		r := sarifRule{
+			ID: rule.ID,
+			Properties: sarifRuleProperties{
+				Category:   rule.Category,
+				Confidence: rule.Confidence,
+				// Code scanning tools, such as GitHub's, recognize CWE entries in this form.
+				Tags: []string{"security", fmt.Sprintf("external/cwe/cwe-%d", rule.CWE)},
+			},
+		}
+		if len(rule.References) > 0 {
+			r.HelpURI = rule.References[0]
+		}
+		driver.Rules = append(driver.Rules, r)
+	}
+
+	results := []sarifResult{}
+
+	for _, f := range fs {
+		result := sarifResult{
+			RuleID:    f.ruleID,
+			RuleIndex: index[f.ruleID],
+			Level:     sarifLevels[f.severity],
+			Message:   sarifMessage{Text: f.message},
+			Locations: []sarifLocation{
+				{PhysicalLocation: sarifPhysical(wd, f.posn.Filename, f.posn.Line, f.posn.Column)},
+			},
+			PartialFingerprints: map[string]string{"rsalint/v1": fingerprint(wd, f)},
+		}
+
+		for i, rel := range f.related {
+			result.RelatedLocations = append(result.RelatedLocations, sarifLocation{
+				ID:               i + 1,
+				PhysicalLocation: sarifPhysical(wd, rel.posn.Filename, rel.posn.Line, rel.posn.Column),
+				Message:          &sarifMessage{Text: rel.message},
+			})
+		}
+
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifPhysical returns the physical location of a position. Paths within the working
+// directory are relative to the %SRCROOT% base, which code scanning tools resolve to
+// the root of the repository, and other paths are file URIs.
+func sarifPhysical(wd, filename string, line, column int) sarifPhysicalLocation {
+	loc := sarifArtifactLocation{URI: relativePath(wd, filename), URIBaseID: sarifSrcRoot}
+	if filepath.IsAbs(filepath.FromSlash(loc.URI)) || strings.HasPrefix(loc.URI, "../") {
+		loc = sarifArtifactLocation{URI: (&url.URL{Scheme: "file", Path: filepath.ToSlash(filename)}).String()}
+	}
+
+	return sarifPhysicalLocation{
+		ArtifactLocation: loc,
+		Region:           sarifRegion{StartLine: line, StartColumn: column},
+	}
+}
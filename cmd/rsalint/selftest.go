@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/picatz/rsalint/rsacheck"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// fixturesDir is the directory of the fixtures embedded in [rsacheck.Fixtures].
+const fixturesDir = "testdata/src"
+
+var (
+	// wantComment matches the expectations in a fixture's "// want" comment.
+	wantComment = regexp.MustCompile(`//\s*want\s+(.*)$`)
+
+	// quoted matches a single quoted expectation.
+	quoted = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+)
+
+// selftest runs the analyzer on the fixtures embedded in [rsacheck.Fixtures], and
+// reports whether the expected findings were reported. It returns the exit code:
+// 0 if every fixture passed, and 1 otherwise.
+func selftest(stdout, stderr io.Writer) int {
+	entries, err := fs.ReadDir(rsacheck.Fixtures, fixturesDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+		return 1
+	}
+
+	code := 0
+	for _, entry := range entries {
+		problems, err := selftestFixture(entry.Name())
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %s: %v\n", rsacheck.Analyzer.Name, entry.Name(), err)
+			return 1
+		}
+
+		if len(problems) > 0 {
+			code = 1
+			fmt.Fprintf(stdout, "FAIL\t%s\n", entry.Name())
+			for _, problem := range problems {
+				fmt.Fprintf(stdout, "\t%s\n", problem)
+			}
+			continue
+		}
+		fmt.Fprintf(stdout, "ok  \t%s\n", entry.Name())
+	}
+
+	return code
+}
+
+// selftestFixture copies the named fixture into a temporary module, runs the analyzer
+// on it, and returns a description of each missing or unexpected finding.
+func selftestFixture(name string) ([]string, error) {
+	dir, err := os.MkdirTemp("", "rsalint-selftest-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	wants := map[string][]*regexp.Regexp{}
+
+	src := path.Join(fixturesDir, name)
+	files, err := fs.ReadDir(rsacheck.Fixtures, src)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		data, err := fs.ReadFile(rsacheck.Fixtures, path.Join(src, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, file.Name()), data, 0o644); err != nil {
+			return nil, err
+		}
+
+		for i, line := range strings.Split(string(data), "\n") {
+			m := wantComment.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+
+			key := fmt.Sprintf("%s:%d", file.Name(), i+1)
+			for _, q := range quoted.FindAllString(m[1], -1) {
+				expr, err := strconv.Unquote(q)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", key, err)
+				}
+
+				re, err := regexp.Compile(expr)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", key, err)
+				}
+
+				wants[key] = append(wants[key], re)
+			}
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module selftest\n\ngo 1.23\n"), 0o644); err != nil {
+		return nil, err
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.LoadAllSyntax | packages.NeedModule,
+		Dir:  dir,
+		Env:  append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod"),
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return nil, fmt.Errorf("error during loading")
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{rsacheck.Analyzer}, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	for _, f := range findings(graph) {
+		key := fmt.Sprintf("%s:%d", filepath.Base(f.posn.Filename), f.posn.Line)
+
+		matched := false
+		for i, re := range wants[key] {
+			if re.MatchString(f.message) {
+				wants[key] = append(wants[key][:i], wants[key][i+1:]...)
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			problems = append(problems, fmt.Sprintf("%s: unexpected finding: %s", key, f.message))
+		}
+	}
+
+	for key, res := range wants {
+		for _, re := range res {
+			problems = append(problems, fmt.Sprintf("%s: missing finding matching %q", key, re))
+		}
+	}
+
+	sort.Strings(problems)
+
+	return problems, nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/picatz/rsalint/rsacheck"
+	"golang.org/x/tools/go/packages"
+)
+
+// request is a request to analyze packages, sent by a client as a single line of JSON.
+type request struct {
+	// Dir is the directory in which to load the packages.
+	Dir string `json:"dir"`
+
+	// Patterns are the patterns of the packages to analyze, such as "./...".
+	Patterns []string `json:"patterns"`
+
+	// Overlay maps absolute file paths to their contents, such as unsaved files
+	// in an editor, which are used instead of the files on disk.
+	Overlay map[string]string `json:"overlay,omitempty"`
+
+	// Flags sets the analyzer's flags for the request, such as {"bulk-encryption": "true"}.
+	Flags map[string]string `json:"flags,omitempty"`
+}
+
+// response is the result of a request, sent to the client as a single line of JSON.
+type response struct {
+	Findings []serverFinding `json:"findings"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// serverFinding is a finding sent to a client.
+type serverFinding struct {
+	Package string `json:"package"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	RuleID  string `json:"rule_id"`
+	Message string `json:"message"`
+}
+
+// serve runs the "serve" subcommand, which listens on a Unix domain socket, so that
+// editors can analyze packages without starting a new process for each check.
+func serve(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet(rsacheck.Analyzer.Name+" serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	socket := fs.String("socket", "", "path of the Unix domain socket to listen on")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 1
+	}
+
+	if *socket == "" {
+		fmt.Fprintf(stderr, "%s: serve requires -socket\n", rsacheck.Analyzer.Name)
+		return 1
+	}
+
+	l, err := net.Listen("unix", *socket)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+		return 1
+	}
+	defer os.Remove(*socket)
+
+	if err := newServer().serve(l); err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", rsacheck.Analyzer.Name, err)
+		return 1
+	}
+	return 0
+}
+
+// server analyzes packages for clients connected to a listener. Each connection
+// can send any number of requests, one per line, and receives one response per
+// request, in order.
+type server struct{}
+
+// newServer returns a new server.
+func newServer() *server {
+	return &server{}
+}
+
+// serve accepts connections on the listener until it's closed.
+func (s *server) serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// handle responds to the requests sent over the connection until it's closed.
+func (s *server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(nil, 64<<20)
+
+	enc := json.NewEncoder(conn)
+
+	for scanner.Scan() {
+		var req request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			enc.Encode(response{Error: err.Error()})
+			continue
+		}
+
+		if err := enc.Encode(s.analyze(req)); err != nil {
+			return
+		}
+	}
+}
+
+// analyze loads and analyzes the packages of the request.
+func (s *server) analyze(req request) response {
+	cfg := &packages.Config{
+		Mode:    packages.LoadAllSyntax | packages.NeedModule,
+		Dir:     req.Dir,
+		Overlay: map[string][]byte{},
+	}
+	for path, contents := range req.Overlay {
+		cfg.Overlay[path] = []byte(contents)
+	}
+
+	pkgs, err := packages.Load(cfg, req.Patterns...)
+	if err != nil {
+		return response{Error: err.Error()}
+	}
+
+	var errs []error
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			errs = append(errs, err)
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return response{Error: err.Error()}
+	}
+
+	findings, err := rsacheck.RunOnPackages(pkgs, rsacheck.Options{Flags: req.Flags})
+	if err != nil {
+		return response{Error: err.Error()}
+	}
+
+	resp := response{Findings: []serverFinding{}}
+	for _, f := range findings {
+		resp.Findings = append(resp.Findings, serverFinding{
+			Package: f.Package,
+			File:    f.Pos.Filename,
+			Line:    f.Pos.Line,
+			Column:  f.Pos.Column,
+			RuleID:  f.RuleID,
+			Message: f.Message,
+		})
+	}
+	return resp
+}
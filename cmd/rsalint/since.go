@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// sinceTime is a point in time, set using a duration before now, such as 24h, or a
+// time in RFC 3339 format or as a date, implementing flag.Value.
+type sinceTime struct {
+	time.Time
+}
+
+func (t *sinceTime) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (t *sinceTime) Set(s string) error {
+	if d, err := time.ParseDuration(s); err == nil {
+		t.Time = time.Now().Add(-d)
+		return nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid duration or time %q", s)
+}
+
+// modifiedSince reports whether the given file was modified after the given time.
+func modifiedSince(filename string, since time.Time) bool {
+	info, err := os.Stat(filename)
+	return err == nil && info.ModTime().After(since)
+}
+
+// filterModifiedPackages returns the packages with at least one file modified after the
+// given time. Packages are still analyzed as a whole, since their files depend on each
+// other, and findings in the files that weren't modified are filtered afterwards.
+func filterModifiedPackages(pkgs []*packages.Package, since time.Time) []*packages.Package {
+	var filtered []*packages.Package
+	for _, pkg := range pkgs {
+		for _, filename := range pkg.CompiledGoFiles {
+			if modifiedSince(filename, since) {
+				filtered = append(filtered, pkg)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// filterModified returns the findings in files modified after the given time.
+func filterModified(fs []finding, since time.Time) []finding {
+	modified := map[string]bool{}
+
+	var filtered []finding
+	for _, f := range fs {
+		ok, seen := modified[f.posn.Filename]
+		if !seen {
+			ok = modifiedSince(f.posn.Filename, since)
+			modified[f.posn.Filename] = ok
+		}
+
+		if ok {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/picatz/rsalint/rsacheck"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema creates the tables findings are exported to, if they don't exist. Each
+// run of the command is a row in runs, and each of its findings a row in findings.
+const sqliteSchema = `CREATE TABLE IF NOT EXISTS runs (
+	id INTEGER PRIMARY KEY,
+	time TEXT NOT NULL,
+	commit_sha TEXT NOT NULL,
+	rules_version INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS findings (
+	run_id INTEGER NOT NULL REFERENCES runs (id),
+	rule_id TEXT NOT NULL,
+	severity TEXT NOT NULL,
+	package TEXT NOT NULL,
+	file TEXT NOT NULL,
+	line INTEGER NOT NULL,
+	message TEXT NOT NULL,
+	fingerprint TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS findings_run_id ON findings (run_id);
+`
+
+// commitEnv are the environment variables checked, in order, for the SHA of the commit
+// that's analyzed, as set by CI systems.
+var commitEnv = []string{"RSALINT_COMMIT", "GITHUB_SHA", "CI_COMMIT_SHA", "GIT_COMMIT"}
+
+// exportSQLite appends a run, at the given time, with its findings to the SQLite database
+// at the given path, creating the database and its tables if they don't exist. The run is
+// appended in a single transaction.
+func exportSQLite(path string, fs []finding, now time.Time) error {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	defer db.Close()
+
+	if err := insertRun(db, fs, now); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return db.Close()
+}
+
+// insertRun creates the tables of the database if they don't exist, and inserts a run
+// with its findings in a single transaction.
+func insertRun(db *sql.DB, fs []finding, now time.Time) error {
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("INSERT INTO runs (time, commit_sha, rules_version) VALUES (?, ?, ?)",
+		now.UTC().Format(time.RFC3339), commitSHA(), rsacheck.RulesVersion)
+	if err != nil {
+		return err
+	}
+
+	runID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	insert, err := tx.Prepare("INSERT INTO findings VALUES (?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer insert.Close()
+
+	wd, _ := os.Getwd()
+
+	for _, f := range fs {
+		_, err := insert.Exec(runID, f.ruleID, string(f.severity), f.pkgPath,
+			relativePath(wd, f.posn.Filename), f.posn.Line, f.message, fingerprint(wd, f))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// commitSHA returns the SHA of the commit that's analyzed, from the environment, or an
+// empty string if it isn't set.
+func commitSHA() string {
+	for _, name := range commitEnv {
+		if sha := os.Getenv(name); sha != "" {
+			return sha
+		}
+	}
+	return ""
+}
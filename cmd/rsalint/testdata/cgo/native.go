@@ -0,0 +1,11 @@
+package keys
+
+// The header doesn't exist, like a C library that isn't installed, so this file
+// can only be loaded with cgo disabled, when it's excluded from the build.
+
+// #include "missing_rsa.h"
+import "C"
+
+func NativeKeySize() int {
+	return int(C.rsa_key_size())
+}
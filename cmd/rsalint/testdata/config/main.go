@@ -0,0 +1,18 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+
+	"example.com/config/relaxed"
+)
+
+func main() {
+	privateKey, err := rsa.GenerateMultiPrimeKey(rand.Reader, 2, 1024)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(privateKey, relaxed.LegacyKey())
+}
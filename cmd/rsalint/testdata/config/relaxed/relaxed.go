@@ -0,0 +1,14 @@
+package relaxed
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func LegacyKey() *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateMultiPrimeKey(rand.Reader, 2, 1024)
+	if err != nil {
+		panic(err)
+	}
+	return privateKey
+}
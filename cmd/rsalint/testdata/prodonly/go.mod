@@ -0,0 +1,3 @@
+module example.com/prodonly
+
+go 1.23.0
@@ -0,0 +1,12 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func main() {
+	rsa.GenerateKey(rand.Reader, 1024)
+
+	GeneratedKey()
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	if _, err := rsa.GenerateKey(rand.Reader, 512); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+)
+
+func main() {
+	privateKey, err := rsa.GenerateMultiPrimeKey(rand.Reader, 2, 1024)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(privateKey)
+}
@@ -0,0 +1,11 @@
+package strict
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+// Encrypt encrypts the message for the given public key.
+func Encrypt(pub *rsa.PublicKey, msg []byte) ([]byte, error) {
+	return rsa.EncryptPKCS1v15(rand.Reader, pub, msg)
+}
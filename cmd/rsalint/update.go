@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+// modulePath is the path of the module the command is released in.
+const modulePath = "github.com/picatz/rsalint"
+
+// updateTimeout limits how long checking for a newer release can take, so that the check
+// never noticeably slows down a run.
+const updateTimeout = 3 * time.Second
+
+// updateClient is the HTTP client used to check for a newer release. It's replaced by tests.
+var updateClient = http.DefaultClient
+
+// checkUpdate starts checking the module proxy for a release newer than the running version,
+// and returns a channel that receives a notice to print if there is one, or an empty string
+// otherwise, including when the check fails, such as without network access.
+func checkUpdate() <-chan string {
+	notice := make(chan string, 1)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), updateTimeout)
+		defer cancel()
+
+		latest, err := latestVersion(ctx, updateClient, moduleProxy())
+		if err != nil {
+			notice <- ""
+			return
+		}
+
+		notice <- updateNotice(currentVersion(), latest)
+	}()
+
+	return notice
+}
+
+// updateNotice returns a notice that the latest version is available, if it's newer than
+// the current version, or an empty string otherwise. Commands built from source don't have
+// a version, and never get a notice.
+func updateNotice(current, latest string) string {
+	if !semver.IsValid(current) || semver.Compare(latest, current) <= 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("a newer version of rsalint is available: %s (running %s)\nupdate with: go install %s/cmd/rsalint@%s", latest, current, modulePath, latest)
+}
+
+// currentVersion returns the version of the running command, which is only a semantic
+// version if it was installed using go install with a version, and not built from a
+// clone of the repository.
+func currentVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	return info.Main.Version
+}
+
+// moduleProxy returns the first module proxy set in GOPROXY, or the default proxy.
+func moduleProxy() string {
+	for _, proxy := range strings.FieldsFunc(os.Getenv("GOPROXY"), func(r rune) bool { return r == ',' || r == '|' }) {
+		if strings.HasPrefix(proxy, "https://") || strings.HasPrefix(proxy, "http://") {
+			return strings.TrimSuffix(proxy, "/")
+		}
+	}
+	return "https://proxy.golang.org"
+}
+
+// latestVersion returns the latest release of the module, queried from the given module
+// proxy using its @latest endpoint.
+func latestVersion(ctx context.Context, client *http.Client, proxy string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, proxy+"/"+modulePath+"/@latest", nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", req.URL, resp.Status)
+	}
+
+	var info struct {
+		Version string
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&info); err != nil {
+		return "", err
+	}
+
+	if !semver.IsValid(info.Version) {
+		return "", fmt.Errorf("%s: invalid version %q", req.URL, info.Version)
+	}
+	return info.Version, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+// vetTool reports whether the command is being invoked by "go vet", which
+// first queries the tool's version and flags, then runs it on a *.cfg file.
+func vetTool(args []string) bool {
+	for _, arg := range args {
+		switch strings.TrimLeft(arg, "-") {
+		case "V", "V=full", "flags":
+			return true
+		}
+	}
+	return len(args) > 0 && strings.HasSuffix(args[len(args)-1], ".cfg")
+}
+
+// redirectVetStdout redirects the standard output of the command to the file
+// named by the Stdout field of the *.cfg file given by "go vet", if any.
+//
+// Newer versions of "go vet" always run the tool with -json, and read the
+// diagnostics from that file rather than the tool's standard output, which
+// the standard driver doesn't know about. Without the redirect, the JSON is
+// printed as is, and findings don't cause "go vet" to fail.
+func redirectVetStdout(args []string) error {
+	if len(args) == 0 || !strings.HasSuffix(args[len(args)-1], ".cfg") {
+		return nil
+	}
+
+	data, err := os.ReadFile(args[len(args)-1])
+	if err != nil {
+		return err
+	}
+
+	var cfg struct {
+		Stdout string
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+
+	if cfg.Stdout == "" {
+		return nil
+	}
+
+	// The file is left open, since the driver exits once it's done.
+	f, err := os.Create(cfg.Stdout)
+	if err != nil {
+		return err
+	}
+	os.Stdout = f
+	return nil
+}
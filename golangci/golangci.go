@@ -0,0 +1,145 @@
+// Package golangci registers the analyzer as a golangci-lint module plugin, named
+// "rsalint", so that it can run alongside other linters in a custom golangci-lint binary.
+//
+// The binary is built using "golangci-lint custom", with a .custom-gcl.yml file that
+// imports this package:
+//
+//	version: v1.62.2
+//	plugins:
+//	  - module: github.com/picatz/rsalint
+//	    import: github.com/picatz/rsalint/golangci
+//
+// The linter is then enabled in the .golangci.yml file, where its settings set the
+// analyzer's flags of the same names:
+//
+//	linters-settings:
+//	  custom:
+//	    rsalint:
+//	      type: module
+//	      description: Reports insecure usage of the crypto/rsa package.
+//	      settings:
+//	        min-bits: 3072
+//	        trusted-readers: [example.com/hsm.Reader]
+//	        exclude: [third_party]
+//	        enable: [bulk-encryption, key-literal]
+//	        disable: [pkcs1v15]
+//	linters:
+//	  enable:
+//	    - rsalint
+package golangci
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/picatz/rsalint/rsacheck"
+)
+
+func init() {
+	register.Plugin("rsalint", New)
+}
+
+// Settings are the settings of the plugin in the .golangci.yml file. Unset settings
+// keep the defaults of [rsacheck.DefaultConfig].
+type Settings struct {
+	// MinBits is the minimum number of bits of RSA keys (-min-bits).
+	MinBits *int `json:"min-bits"`
+
+	// StrictRand reports random readers that can't be resolved (-strict-rand).
+	StrictRand *bool `json:"strict-rand"`
+
+	// TrustedReaders are functions or variables trusted as secure random readers
+	// (-trusted-readers).
+	TrustedReaders []string `json:"trusted-readers"`
+
+	// Exclude are path globs of files and directories to not report findings in
+	// (-exclude).
+	Exclude []string `json:"exclude"`
+
+	// LintGenerated reports findings in generated files (-lint-generated).
+	LintGenerated bool `json:"lint-generated"`
+
+	// PublicOnly only reports findings in the public API surface (-public-only).
+	PublicOnly bool `json:"public-only"`
+
+	// SkipBenchmarks skips Benchmark functions (-skip-benchmarks).
+	SkipBenchmarks bool `json:"skip-benchmarks"`
+
+	// MinSeverity is the minimum severity of the findings to report, such as
+	// "warning" (-min-severity).
+	MinSeverity string `json:"min-severity"`
+
+	// Enable are the optional checks to enable, named as their flags, such as
+	// "bulk-encryption".
+	Enable []string `json:"enable"`
+
+	// Disable are the groups of checks to disable, such as "pkcs1v15" for
+	// -disable-pkcs1v15.
+	Disable []string `json:"disable"`
+}
+
+// plugin is the golangci-lint plugin of the analyzer.
+type plugin struct {
+	settings Settings
+}
+
+// New returns the plugin with the given settings from the .golangci.yml file.
+func New(settings any) (register.LinterPlugin, error) {
+	s, err := register.DecodeSettings[Settings](settings)
+	if err != nil {
+		return nil, fmt.Errorf("rsalint: %w", err)
+	}
+	return &plugin{settings: s}, nil
+}
+
+// BuildAnalyzers returns an analyzer configured with the plugin's settings, which is
+// created for the plugin, rather than setting the flags of the global analyzer.
+func (p *plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	a := rsacheck.NewAnalyzer(rsacheck.DefaultConfig)
+
+	s := p.settings
+
+	flags := map[string]string{
+		"trusted-readers": strings.Join(s.TrustedReaders, ","),
+		"exclude":         strings.Join(s.Exclude, ","),
+		"lint-generated":  strconv.FormatBool(s.LintGenerated),
+		"public-only":     strconv.FormatBool(s.PublicOnly),
+		"skip-benchmarks": strconv.FormatBool(s.SkipBenchmarks),
+	}
+	if s.MinBits != nil {
+		flags["min-bits"] = strconv.Itoa(*s.MinBits)
+	}
+	if s.MinSeverity != "" {
+		flags["min-severity"] = s.MinSeverity
+	}
+	if s.StrictRand != nil {
+		flags["strict-rand"] = strconv.FormatBool(*s.StrictRand)
+	}
+	for _, check := range s.Enable {
+		flags[check] = "true"
+	}
+	for _, group := range s.Disable {
+		flags["disable-"+group] = "true"
+	}
+
+	for name, value := range flags {
+		if a.Flags.Lookup(name) == nil {
+			return nil, fmt.Errorf("rsalint: unknown setting %q", name)
+		}
+		if err := a.Flags.Set(name, value); err != nil {
+			return nil, fmt.Errorf("rsalint: invalid setting %q: %w", name, err)
+		}
+	}
+
+	return []*analysis.Analyzer{a}, nil
+}
+
+// GetLoadMode returns the load mode of the analyzer, which needs type information to
+// build the SSA form of packages.
+func (p *plugin) GetLoadMode() string {
+	return register.LoadModeTypesInfo
+}
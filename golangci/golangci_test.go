@@ -0,0 +1,76 @@
+//go:build golangci
+
+// The plugin is tested with "go test -tags golangci ./golangci", alongside golangci-lint
+// upgrades, since it depends on the plugin registration API of golangci-lint.
+
+package golangci
+
+import (
+	"testing"
+
+	"github.com/golangci/plugin-module-register/register"
+)
+
+func TestPlugin(t *testing.T) {
+	newPlugin, err := register.GetPlugin("rsalint")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := newPlugin(map[string]any{
+		"min-bits":     3072,
+		"min-severity": "warning",
+		"exclude":      []any{"third_party"},
+		"enable":       []any{"bulk-encryption"},
+		"disable":      []any{"pkcs1v15"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mode := p.GetLoadMode(); mode != register.LoadModeTypesInfo {
+		t.Errorf("expected load mode %q, got %q", register.LoadModeTypesInfo, mode)
+	}
+
+	analyzers, err := p.BuildAnalyzers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(analyzers) != 1 || analyzers[0].Name != "rsalint" {
+		t.Fatalf("expected the rsalint analyzer, got %v", analyzers)
+	}
+
+	for name, want := range map[string]string{
+		"min-bits":         "3072",
+		"strict-rand":      "true",
+		"min-severity":     "warning",
+		"exclude":          "third_party",
+		"bulk-encryption":  "true",
+		"disable-pkcs1v15": "true",
+		"disable-random":   "false",
+	} {
+		if got := analyzers[0].Flags.Lookup(name).Value.String(); got != want {
+			t.Errorf("expected -%s to be %s, got %s", name, want, got)
+		}
+	}
+}
+
+func TestPluginInvalidSettings(t *testing.T) {
+	for _, settings := range []map[string]any{
+		{"unknown": true},
+		{"enable": []any{"unknown-check"}},
+		{"disable": []any{"unknown-group"}},
+		{"exclude": []any{"legacy/["}},
+		{"min-severity": "critical"},
+	} {
+		p, err := New(settings)
+		if err != nil {
+			continue
+		}
+
+		if _, err := p.BuildAnalyzers(); err == nil {
+			t.Errorf("expected an error for settings %v", settings)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package rsacheck
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// allowWeakTag is a build tag that suppresses all findings in files that require it,
+// giving source-level control over code that intentionally uses weak parameters, such
+// as test helpers built with "go test -tags rsalint_allow_weak".
+const allowWeakTag = "rsalint_allow_weak"
+
+// allowedWeak reports whether findings at the given position are suppressed, because
+// the file containing it requires the [allowWeakTag] build tag.
+func allowedWeak(pass *analysis.Pass, pos token.Pos) bool {
+	file, ok := fileOf(pass, pos)
+	return ok && allowWeakFile(file)
+}
+
+// fileOf returns the file of the package being analyzed that contains the given position.
+func fileOf(pass *analysis.Pass, pos token.Pos) (*ast.File, bool) {
+	for _, file := range pass.Files {
+		if file.FileStart <= pos && pos <= file.FileEnd {
+			return file, true
+		}
+	}
+	return nil, false
+}
+
+// allowWeakFile reports whether the given file's build constraint requires the
+// [allowWeakTag] build tag.
+func allowWeakFile(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+
+		for _, comment := range group.List {
+			expr, err := constraint.Parse(comment.Text)
+			if err == nil && requiresTags(expr, map[string]bool{allowWeakTag: true}) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package rsacheck
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// Options configures a run of the analyzer using [RunOnPackages].
+type Options struct {
+	// Flags sets the analyzer's flags by name for the run, such as
+	// {"bulk-encryption": "true"}. Flags that aren't set have their default values.
+	Flags map[string]string
+}
+
+// Finding is a diagnostic reported by the analyzer, resolved to its position.
+type Finding struct {
+	// Package is the import path of the package the finding was reported in.
+	Package string
+
+	// Pos is the position of the finding.
+	Pos token.Position
+
+	// End is the end position of the finding, if known.
+	End token.Position
+
+	// RuleID is the ID of the rule that reported the finding, such as "RSA002".
+	RuleID string
+
+	// Message describes the finding.
+	Message string
+}
+
+// RunOnPackages runs the analyzer on packages that were already loaded, such as by
+// tools that use [golang.org/x/tools/go/packages] themselves. The packages must be
+// loaded with at least [packages.LoadAllSyntax], and the analyzer is run on each of
+// them, but not on their dependencies.
+//
+// Each run uses its own analyzer, configured by the options rather than the flags of
+// [Analyzer], so runs can happen concurrently.
+//
+// Findings are de-duplicated by position and message, since files can belong to more
+// than one package, such as a package and its test variant.
+func RunOnPackages(pkgs []*packages.Package, opts Options) ([]Finding, error) {
+	a := NewAnalyzer(DefaultConfig)
+
+	for name, value := range opts.Flags {
+		f := a.Flags.Lookup(name)
+		if f == nil {
+			return nil, fmt.Errorf("rsacheck: unknown flag %q", name)
+		}
+
+		if err := f.Value.Set(value); err != nil {
+			return nil, fmt.Errorf("rsacheck: invalid value %q for flag %q: %w", value, name, err)
+		}
+	}
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{a}, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		pos     token.Position
+		message string
+	}
+
+	var (
+		seen     = map[key]bool{}
+		findings []Finding
+		errs     []error
+	)
+
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", act.Package.PkgPath, act.Err))
+			continue
+		}
+
+		for _, diag := range act.Diagnostics {
+			f := Finding{
+				Package: act.Package.PkgPath,
+				Pos:     act.Package.Fset.Position(diag.Pos),
+				End:     act.Package.Fset.Position(diag.End),
+				RuleID:  diag.Category,
+				Message: diag.Message,
+			}
+
+			k := key{f.Pos, f.Message}
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+
+			findings = append(findings, f)
+		}
+	}
+
+	return findings, errors.Join(errs...)
+}
@@ -0,0 +1,203 @@
+package rsacheck
+
+import (
+	"flag"
+	"slices"
+	"strconv"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+)
+
+// Config configures the checks of an analyzer created using [NewAnalyzer], so that it
+// can be embedded in other programs without setting the flags of the global [Analyzer].
+// Each field corresponds to a flag of the analyzer, which sets it.
+type Config struct {
+	// MinBits is the minimum number of bits of RSA keys; smaller keys are reported as
+	// weak (-min-bits).
+	MinBits int
+
+	// StrictRand reports random readers that can't be resolved. If false, only readers
+	// known to be weak are reported (-strict-rand).
+	StrictRand bool
+
+	// TrustedReaders are fully qualified functions or variables trusted as secure random
+	// readers, such as "example.com/hsm.Reader" (-trusted-readers).
+	TrustedReaders []string
+
+	// Exclude are path globs, using [path/filepath.Match] syntax, of files and
+	// directories to not report findings in (-exclude).
+	Exclude []string
+
+	// LintGenerated reports findings in generated files (-lint-generated).
+	LintGenerated bool
+
+	// PublicOnly only reports findings in exported functions, and the functions they
+	// pass their parameters to (-public-only).
+	PublicOnly bool
+
+	// SkipBenchmarks skips Benchmark functions in _test.go files (-skip-benchmarks).
+	SkipBenchmarks bool
+
+	// ShowRuleIDs prefixes messages with the ID of their rule, such as [RSA002]
+	// (-show-rule-ids).
+	ShowRuleIDs bool
+
+	// MinSeverity is the minimum severity of the rules whose findings are reported, such
+	// as "warning" to skip advisories. If empty, findings of any severity are reported
+	// (-min-severity).
+	MinSeverity Severity
+
+	// Disable are the names of the groups of checks to disable, such as "random"
+	// (-disable-random).
+	Disable []string
+
+	// Optional checks that are disabled by default, named as their flags.
+	BulkEncryption         bool
+	PooledReader           bool
+	EncryptInLoop          bool
+	StoredCiphertext       bool
+	KeyDeepEqual           bool
+	UnvalidatedKeySize     bool
+	UnauthenticatedDecrypt bool
+	SeededRand             bool
+	MutableReader          bool
+	RecoveredKeyGen        bool
+	UnmarshaledBits        bool
+	HardcodedKeyCompare    bool
+	WasmReader             bool
+	GobPrivateKey          bool
+	TestHelperReader       bool
+	NilReader              bool
+	TestKeyLeak            bool
+	LongValidity           bool
+	FeatureFlags           bool
+	InsecureSkipVerify     bool
+	GeneratedBits          bool
+	KeyLiteral             bool
+	CheckErrors            bool
+	Perf                   bool
+	PlaintextKey           bool
+	SuggestEdDSA           bool
+}
+
+// DefaultConfig is the configuration of the global [Analyzer].
+var DefaultConfig = Config{
+	MinBits:     2048,
+	StrictRand:  true,
+	MinSeverity: SeverityInfo,
+}
+
+// NewAnalyzer returns an analyzer that checks for the same insecure usage of the
+// "crypto/rsa" package as [Analyzer], configured using the given configuration. Its
+// flags set its own copy of the configuration, rather than the global analyzer's.
+func NewAnalyzer(cfg Config) *analysis.Analyzer {
+	cfg.TrustedReaders = slices.Clone(cfg.TrustedReaders)
+	cfg.Exclude = slices.Clone(cfg.Exclude)
+	cfg.Disable = slices.Clone(cfg.Disable)
+
+	a := &analysis.Analyzer{
+		Name: "rsalint",
+		Doc:  "report insecure usage of the \"crypto/rsa\" package",
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			return run(pass, &cfg)
+		},
+		Requires: []*analysis.Analyzer{
+			buildssa.Analyzer,
+		},
+	}
+
+	registerFlags(&a.Flags, &cfg)
+
+	return a
+}
+
+// registerFlags registers the flags that set the given configuration.
+func registerFlags(fs *flag.FlagSet, cfg *Config) {
+	fs.BoolVar(&cfg.BulkEncryption, "bulk-encryption", cfg.BulkEncryption, "report RSA encryption of file contents (os.ReadFile, io.ReadAll)")
+	fs.BoolVar(&cfg.PooledReader, "pooled-reader", cfg.PooledReader, "report random readers obtained from a sync.Pool")
+	fs.BoolVar(&cfg.EncryptInLoop, "encrypt-in-loop", cfg.EncryptInLoop, "report rsa.EncryptOAEP calls in unbounded loops")
+	fs.BoolVar(&cfg.KeyDeepEqual, "key-deep-equal", cfg.KeyDeepEqual, "report RSA private keys compared with reflect.DeepEqual")
+	fs.BoolVar(&cfg.UnvalidatedKeySize, "unvalidated-key-size", cfg.UnvalidatedKeySize, "report keys generated with a non-constant number of bits whose size isn't validated")
+	fs.BoolVar(&cfg.StoredCiphertext, "stored-ciphertext", cfg.StoredCiphertext, "raise the priority of rsa.EncryptPKCS1v15 ciphertexts that are base64-encoded and stored")
+	fs.BoolVar(&cfg.UnauthenticatedDecrypt, "unauthenticated-decrypt", cfg.UnauthenticatedDecrypt, "report decryption of HTTP request bodies without verifying a MAC or signature first")
+	fs.BoolVar(&cfg.SeededRand, "seeded-rand", cfg.SeededRand, "raise the confidence of weak random readers in packages that call math/rand.Seed")
+	fs.BoolVar(&cfg.MutableReader, "mutable-reader", cfg.MutableReader, "report random readers that are package variables reassigned outside of their declaration, including in tests")
+	fs.BoolVar(&cfg.RecoveredKeyGen, "recovered-keygen", cfg.RecoveredKeyGen, "report functions that recover from panics around RSA key generation")
+	fs.BoolVar(&cfg.UnmarshaledBits, "unmarshaled-bits", cfg.UnmarshaledBits, "report key sizes unmarshaled from JSON, YAML, or protobuf without enforcing a minimum")
+	fs.BoolVar(&cfg.HardcodedKeyCompare, "hardcoded-key-compare", cfg.HardcodedKeyCompare, "report RSA key moduli or private exponents compared to hardcoded values")
+	fs.BoolVar(&cfg.WasmReader, "wasm-reader", cfg.WasmReader, "report weak fallback random readers in files only built for WebAssembly")
+	fs.BoolVar(&cfg.GobPrivateKey, "gob-private-key", cfg.GobPrivateKey, "report RSA private keys serialized using encoding/gob")
+	fs.BoolVar(&cfg.PlaintextKey, "plaintext-key", cfg.PlaintextKey, "report RSA private keys marshaled by x509.MarshalPKCS1PrivateKey or x509.MarshalPKCS8PrivateKey that are written out without being encrypted")
+	fs.BoolVar(&cfg.TestHelperReader, "test-helper-reader", cfg.TestHelperReader, "report random readers from test helper packages, such as testutil or mocks, in non-test files")
+	fs.BoolVar(&cfg.NilReader, "nil-reader", cfg.NilReader, "report random reader parameters passed to RSA functions without handling nil")
+	fs.BoolVar(&cfg.TestKeyLeak, "test-key-leak", cfg.TestKeyLeak, "report keys generated in TestMain or Example functions that are stored in package variables declared in non-test code")
+	fs.BoolVar(&cfg.LongValidity, "long-validity", cfg.LongValidity, "report keys with less than 3072 bits used for X.509 certificates valid for more than 10 years")
+	fs.BoolVar(&cfg.FeatureFlags, "feature-flags", cfg.FeatureFlags, "report weak key sizes selected on the default path of package-level boolean feature flags")
+	fs.BoolVar(&cfg.InsecureSkipVerify, "insecure-skip-verify", cfg.InsecureSkipVerify, "report functions that use an RSA key and set InsecureSkipVerify in a TLS configuration")
+	fs.BoolVar(&cfg.GeneratedBits, "generated-bits", cfg.GeneratedBits, "report key sizes set by constants or variables declared in generated files, which are harder to audit")
+	fs.BoolVar(&cfg.KeyLiteral, "key-literal", cfg.KeyLiteral, "report rsa.PublicKey and rsa.PrivateKey struct literals with a weak modulus, or whose size isn't validated")
+	fs.BoolVar(&cfg.CheckErrors, "check-errors", cfg.CheckErrors, "report errors returned by rsa.GenerateKey that are ignored while the key is used, which overlaps with errcheck")
+	fs.BoolVar(&cfg.SuggestEdDSA, "suggest-eddsa", cfg.SuggestEdDSA, "suggest Ed25519 or ECDSA, once per package, for RSA keys generated only to sign")
+	fs.BoolVar(&cfg.Perf, "perf", cfg.Perf, "report performance advisories, such as generated keys used to sign or decrypt in a loop without calling Precompute")
+
+	fs.Var((*readerList)(&cfg.TrustedReaders), "trusted-readers", "comma-separated list of functions or variables trusted as secure random readers (e.g. example.com/hsm.Reader)")
+	fs.Var((*globList)(&cfg.Exclude), "exclude", "comma-separated list of path globs (e.g. vendor/*,internal/**/*_legacy.go) of files and directories to not report findings in; relative globs match the trailing elements of paths, unlike rsalint -include")
+	fs.BoolVar(&cfg.LintGenerated, "lint-generated", cfg.LintGenerated, "report findings in generated files, which have a \"// Code generated ... DO NOT EDIT.\" comment")
+	fs.BoolVar(&cfg.PublicOnly, "public-only", cfg.PublicOnly, "only report findings in exported functions, and the functions they pass their parameters to")
+	fs.BoolVar(&cfg.SkipBenchmarks, "skip-benchmarks", cfg.SkipBenchmarks, "skip Benchmark functions in _test.go files, which often generate keys with fixed parameters")
+	fs.IntVar(&cfg.MinBits, "min-bits", cfg.MinBits, "minimum number of bits of RSA keys; smaller keys are reported as weak")
+	fs.Var((*severityFlag)(&cfg.MinSeverity), "min-severity", "minimum severity of the findings to report: error, warning, or info")
+	fs.BoolVar(&cfg.ShowRuleIDs, "show-rule-ids", cfg.ShowRuleIDs, "prefix messages with the ID of their rule, such as [RSA002]")
+	fs.BoolVar(&cfg.StrictRand, "strict-rand", cfg.StrictRand, "report random readers that can't be resolved; if false, only readers known to be weak are reported")
+
+	for _, group := range checkGroups {
+		fs.Var(&groupFlag{disable: &cfg.Disable, name: group.name}, "disable-"+group.name, "disable "+group.doc)
+	}
+}
+
+// groupFlag is the -disable-<name> flag of a group of checks, which adds the group to,
+// or removes it from, the disabled groups of a configuration.
+type groupFlag struct {
+	disable *[]string
+	name    string
+}
+
+func (f *groupFlag) String() string {
+	if f.disable == nil {
+		return "false"
+	}
+	return strconv.FormatBool(slices.Contains(*f.disable, f.name))
+}
+
+func (f *groupFlag) Set(s string) error {
+	disabled, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+
+	*f.disable = slices.DeleteFunc(*f.disable, func(name string) bool { return name == f.name })
+	if disabled {
+		*f.disable = append(*f.disable, f.name)
+	}
+	return nil
+}
+
+func (f *groupFlag) IsBoolFlag() bool {
+	return true
+}
+
+// passConfigs maps each pass being run to the configuration of its analyzer, so that
+// checks can look up their configuration from the pass they're given.
+var passConfigs sync.Map
+
+// configOf returns the configuration of the analyzer running the given pass.
+func configOf(pass *analysis.Pass) *Config {
+	if cfg, ok := passConfigs.Load(pass); ok {
+		return cfg.(*Config)
+	}
+
+	cfg := DefaultConfig
+	return &cfg
+}
@@ -0,0 +1,97 @@
+package rsacheck
+
+import (
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// Functions that authenticate a message or ciphertext before it's decrypted.
+var verifyFunctions = []string{
+	"crypto/hmac.Equal",
+	"crypto/subtle.ConstantTimeCompare",
+	"crypto/ed25519.Verify",
+	"crypto/ecdsa.Verify",
+	"crypto/ecdsa.VerifyASN1",
+	verifyPKCS1v15,
+	verifyPSS,
+}
+
+// checkDecrypt checks if the [crypto/rsa.DecryptOAEP] or [crypto/rsa.DecryptPKCS1v15]
+// functions are being used securely.
+func checkDecrypt(pass *analysis.Pass, instr *ssa.Call, ciphertext ssa.Value) {
+	checkUnauthenticatedDecrypt(pass, instr, ciphertext)
+}
+
+// checkUnauthenticatedDecrypt checks if the ciphertext being decrypted is read from
+// an HTTP request body, without first verifying a MAC or signature in the same
+// function. RSA encryption isn't authenticated, so attacker-supplied ciphertexts
+// can be used as a decryption oracle.
+//
+// This is a heuristic: only ciphertexts read directly from the request body using
+// io.ReadAll are considered untrusted, and verification in another function, such
+// as a middleware, isn't detected.
+func checkUnauthenticatedDecrypt(pass *analysis.Pass, instr *ssa.Call, ciphertext ssa.Value) {
+	if !configOf(pass).UnauthenticatedDecrypt {
+		return
+	}
+
+	read, ok := callTo(ciphertext, ioReadAll)
+	if !ok || !requestBody(read.Call.Args[0]) {
+		return
+	}
+
+	for _, b := range instr.Parent().Blocks {
+		for _, other := range b.Instrs {
+			call, ok := other.(*ssa.Call)
+			if !ok {
+				continue
+			}
+
+			if _, ok := callTo(call, verifyFunctions...); ok && precedes(call, instr) {
+				return
+			}
+		}
+	}
+
+	report(pass, unauthenticatedDecryptMessage, analysis.Diagnostic{
+		Pos:     instr.Pos(),
+		Message: unauthenticatedDecryptMessage,
+		Related: []analysis.RelatedInformation{
+			{Pos: read.Pos(), Message: "ciphertext is read from the request body here"},
+		},
+	})
+}
+
+// requestBody reports whether the given reader is the Body of a [net/http.Request].
+func requestBody(reader ssa.Value) bool {
+	load, ok := unwrapInterface(reader).(*ssa.UnOp)
+	if !ok {
+		return false
+	}
+
+	addr, ok := load.X.(*ssa.FieldAddr)
+	if !ok || fieldName(addr) != "Body" {
+		return false
+	}
+
+	return isType(addr.X.Type(), "net/http", "Request")
+}
+
+// precedes reports whether the first instruction is always executed before the
+// second one, because it comes earlier in a block that dominates the second's.
+func precedes(first, second ssa.Instruction) bool {
+	if first.Block() != second.Block() {
+		return first.Block().Dominates(second.Block())
+	}
+
+	for _, instr := range first.Block().Instrs {
+		switch instr {
+		case first:
+			return true
+		case second:
+			return false
+		}
+	}
+
+	return false
+}
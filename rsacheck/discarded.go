@@ -0,0 +1,165 @@
+package rsacheck
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkDiscardedKey checks if the key generated by the given call is assigned to a variable,
+// but never used, often since it's overwritten first, such as by a copy-pasted call that
+// generates another key. Keys assigned to the blank identifier are intentionally discarded,
+// such as when only the error is checked, and aren't reported.
+func checkDiscardedKey(pass *analysis.Pass, instr *ssa.Call) {
+	for _, ref := range *instr.Referrers() {
+		if key, ok := ref.(*ssa.Extract); ok && key.Index == 0 && len(*key.Referrers()) > 0 {
+			return
+		}
+	}
+
+	ident, ok := assignedKey(pass, instr)
+	if !ok {
+		return
+	}
+
+	pos, ok := nextAssignment(pass, ident)
+	if !ok {
+		reportf(pass, instr.Pos(), unusedKeyMessage)
+		return
+	}
+
+	report(pass, discardedKeyMessage, analysis.Diagnostic{
+		Pos:     instr.Pos(),
+		Message: discardedKeyMessage,
+		Related: []analysis.RelatedInformation{
+			{Pos: pos, Message: ident.Name + " is overwritten here"},
+		},
+	})
+}
+
+// checkIgnoredError checks if the error returned by the given key generation call is
+// ignored, such as key, _ := rsa.GenerateKey(rand.Reader, 2048), while the key is used.
+// If generation fails, the key is nil, and using it panics, or worse, silently skips the
+// operation it's used for.
+func checkIgnoredError(pass *analysis.Pass, instr *ssa.Call) {
+	if !configOf(pass).CheckErrors {
+		return
+	}
+
+	var keyUsed bool
+
+	for _, ref := range *instr.Referrers() {
+		result, ok := ref.(*ssa.Extract)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case result.Index == 0 && used(result):
+			keyUsed = true
+		case result.Index == 1 && used(result):
+			return
+		}
+	}
+
+	if keyUsed {
+		reportf(pass, instr.Pos(), ignoredErrorMessage, strings.TrimPrefix(calleeName(instr), "crypto/"))
+	}
+}
+
+// used reports whether the given value is used by an instruction other than a debug
+// reference to the variable it's assigned to.
+func used(value ssa.Value) bool {
+	for _, ref := range *value.Referrers() {
+		if _, ok := ref.(*ssa.DebugRef); !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// assignedKey returns the variable that the key returned by the given call is assigned to,
+// unless it's the blank identifier.
+func assignedKey(pass *analysis.Pass, instr *ssa.Call) (*ast.Ident, bool) {
+	call, ok := callExpr(pass, instr)
+	if !ok {
+		return nil, false
+	}
+
+	for _, file := range pass.Files {
+		if call.Pos() < file.Pos() || call.Pos() > file.End() {
+			continue
+		}
+
+		path, _ := astutil.PathEnclosingInterval(file, call.Pos(), call.End())
+		for _, node := range path {
+			assign, ok := node.(*ast.AssignStmt)
+			if !ok {
+				continue
+			}
+
+			if len(assign.Rhs) != 1 || astutil.Unparen(assign.Rhs[0]) != call || len(assign.Lhs) == 0 {
+				return nil, false
+			}
+
+			ident, ok := assign.Lhs[0].(*ast.Ident)
+			if !ok || ident.Name == "_" {
+				return nil, false
+			}
+			return ident, true
+		}
+	}
+
+	return nil, false
+}
+
+// nextAssignment returns the position of the first assignment to the given variable after
+// the given identifier.
+func nextAssignment(pass *analysis.Pass, ident *ast.Ident) (token.Pos, bool) {
+	obj := pass.TypesInfo.ObjectOf(ident)
+	if obj == nil {
+		return token.NoPos, false
+	}
+
+	pos := token.NoPos
+
+	for _, file := range pass.Files {
+		if ident.Pos() < file.Pos() || ident.Pos() > file.End() {
+			continue
+		}
+
+		ast.Inspect(file, func(node ast.Node) bool {
+			assign, ok := node.(*ast.AssignStmt)
+			if !ok || pos.IsValid() {
+				return pos == token.NoPos
+			}
+
+			for _, lhs := range assign.Lhs {
+				if lhs, ok := lhs.(*ast.Ident); ok && lhs.Pos() > ident.Pos() && pass.TypesInfo.ObjectOf(lhs) == obj {
+					pos = assign.Pos()
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	return pos, pos.IsValid()
+}
+
+// checkDiscardedSignature checks if the signature returned by the given call to a signing
+// function is never used, such as when only its error is checked, which makes signing
+// pointless, and often means the signature is mistakenly dropped instead of sent or stored.
+func checkDiscardedSignature(pass *analysis.Pass, instr *ssa.Call) {
+	for _, ref := range *instr.Referrers() {
+		if sig, ok := ref.(*ssa.Extract); ok && sig.Index == 0 && len(*sig.Referrers()) > 0 {
+			return
+		}
+	}
+
+	reportf(pass, instr.Pos(), discardedSignatureMessage, strings.TrimPrefix(calleeName(instr), "crypto/"))
+}
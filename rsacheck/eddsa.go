@@ -0,0 +1,90 @@
+package rsacheck
+
+import (
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// Methods of private keys that don't decrypt, or expose the key to code that may.
+const (
+	privateKeySign     = "(*crypto/rsa.PrivateKey).Sign"
+	privateKeyPublic   = "(*crypto/rsa.PrivateKey).Public"
+	privateKeyValidate = "(*crypto/rsa.PrivateKey).Validate"
+)
+
+// checkSuggestEdDSA suggests Ed25519 or ECDSA instead of RSA for keys that are generated
+// by [crypto/rsa.GenerateKey] only to sign, since elliptic curve keys and signatures are
+// much smaller, and faster to generate and sign with. It's an advisory for new code,
+// reported once per package, at the first such key's generation.
+//
+// Keys that escape the function that generates them, such as by being returned or
+// stored, may be used to decrypt elsewhere, and aren't reported.
+func checkSuggestEdDSA(pass *analysis.Pass, funcs []*ssa.Function) {
+	if !configOf(pass).SuggestEdDSA {
+		return
+	}
+
+	uses, order := keyUsage(funcs)
+
+	for _, key := range order {
+		call, ok := key.(*ssa.Call)
+		if !ok || calleeName(call) != generateKey {
+			continue
+		}
+
+		u := uses[key]
+		if u.sign == nil || u.decrypt != nil || !onlySigns(call) {
+			continue
+		}
+
+		report(pass, suggestEdDSAMessage, analysis.Diagnostic{
+			Pos:     call.Pos(),
+			Message: suggestEdDSAMessage,
+			Related: []analysis.RelatedInformation{
+				{Pos: u.sign.Pos(), Message: "key is used for signing here"},
+			},
+		})
+		return
+	}
+}
+
+// onlySigns reports whether the private key generated by the given call is only used to
+// sign, or for its public key, and not in any other way, such as to decrypt, or by being
+// returned or stored.
+func onlySigns(generate *ssa.Call) bool {
+	for _, ref := range *generate.Referrers() {
+		key, ok := ref.(*ssa.Extract)
+		if !ok || key.Index != 0 {
+			continue
+		}
+
+		for _, ref := range *key.Referrers() {
+			switch ref := ref.(type) {
+			case *ssa.DebugRef:
+			case *ssa.FieldAddr:
+				// The public key can be shared to verify signatures, but keys whose
+				// public key is used to encrypt are used to decrypt too.
+				if fieldName(ref) != "PublicKey" {
+					return false
+				}
+				if _, ok := flowsTo(ref, encryptPKCS1v15, encryptOAEP); ok {
+					return false
+				}
+			case *ssa.Call:
+				switch name := checkedCallee(ref); name {
+				case privateKeySign, privateKeyPublic, privateKeyValidate, privateKeyPrecompute:
+				case signPKCS1v15, signPSS:
+					if ref.Call.Args[keyArgs[name].index] != key {
+						return false
+					}
+				default:
+					return false
+				}
+			default:
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,85 @@
+package rsacheck
+
+import (
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"golang.org/x/tools/go/analysis"
+)
+
+// globList is a comma-separated list of path globs given to the -exclude flag. Globs use
+// the same syntax as the -include flag of the rsalint command, that of
+// [path/filepath.Match], extended with "**" to match any number of directories.
+type globList []string
+
+func (l *globList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *globList) Set(s string) error {
+	*l = nil
+	for _, pattern := range strings.Split(s, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if !doublestar.ValidatePathPattern(pattern) {
+			return fmt.Errorf("invalid pattern %q", pattern)
+		}
+		*l = append(*l, filepath.Clean(pattern))
+	}
+	return nil
+}
+
+// excluded reports whether the file of the given position is excluded by the globs of the
+// configuration, set using -exclude.
+func excluded(pass *analysis.Pass, cfg *Config, pos token.Pos) bool {
+	if len(cfg.Exclude) == 0 || !pos.IsValid() {
+		return false
+	}
+	return globList(cfg.Exclude).match(pass.Fset.Position(pos).Filename)
+}
+
+// match reports whether one of the globs matches the file, or one of the directories it's
+// in, so that a glob such as vendor/legacy excludes the whole directory. Absolute globs
+// are matched against the absolute path, and relative globs against the trailing
+// elements of the path, such that legacy/*.go matches /src/app/legacy/keys.go, and
+// internal/** matches /src/app/internal/crypto/keys.go.
+//
+// Unlike -include, relative globs aren't anchored at the working directory, since the
+// analyzer may be run by drivers, such as go vet, that run it in other directories.
+func (l globList) match(filename string) bool {
+	for name := filepath.Clean(filename); ; {
+		for _, pattern := range l {
+			if matchTrailing(pattern, name) {
+				return true
+			}
+		}
+
+		dir := filepath.Dir(name)
+		if dir == name || dir == "." {
+			return false
+		}
+		name = dir
+	}
+}
+
+// matchTrailing reports whether the glob matches the path, if it's absolute, or any of
+// its trailing elements otherwise.
+func matchTrailing(pattern, name string) bool {
+	if filepath.IsAbs(pattern) {
+		ok, _ := doublestar.PathMatch(pattern, name)
+		return ok
+	}
+
+	elems := strings.Split(name, string(filepath.Separator))
+	for i := len(elems) - 1; i >= 0; i-- {
+		if ok, _ := doublestar.PathMatch(pattern, filepath.Join(elems[i:]...)); ok {
+			return true
+		}
+	}
+	return false
+}
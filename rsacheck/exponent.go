@@ -0,0 +1,110 @@
+package rsacheck
+
+import (
+	"go/constant"
+	"strconv"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// Methods of math/big.Int used to build a public exponent.
+const (
+	bigIntSetInt64  = "(*math/big.Int).SetInt64"
+	bigIntSetUint64 = "(*math/big.Int).SetUint64"
+	bigIntInt64     = "(*math/big.Int).Int64"
+	bigIntUint64    = "(*math/big.Int).Uint64"
+)
+
+// constExponent returns the constant value of a public exponent, which is either a
+// constant, or converted from a big.Int holding a constant, such as
+// int(e.Int64()) after e.SetString("3", 10).
+func constExponent(value ssa.Value) (int64, bool) {
+	if c, ok := value.(*ssa.Const); ok {
+		return c.Int64(), true
+	}
+
+	conv, ok := value.(*ssa.Convert)
+	if !ok {
+		return 0, false
+	}
+
+	call, ok := conv.X.(*ssa.Call)
+	if !ok {
+		return 0, false
+	}
+
+	switch call.Call.Value.String() {
+	case bigIntInt64, bigIntUint64:
+		return constBigIntValue(call.Call.Args[0])
+	}
+
+	return 0, false
+}
+
+// constBigIntValue returns the constant value of the given big.Int, if it's created
+// with big.NewInt, or set using SetString, SetInt64, or SetUint64, either on the
+// returned value, or on a variable holding the big.Int.
+func constBigIntValue(value ssa.Value) (int64, bool) {
+	if extract, ok := value.(*ssa.Extract); ok && extract.Index == 0 {
+		value = extract.Tuple
+	}
+
+	if call, ok := value.(*ssa.Call); ok {
+		if n, ok := bigIntSetter(call); ok {
+			return n, true
+		}
+	}
+
+	// The big.Int is set after it's created, such as e := new(big.Int); e.SetInt64(3).
+	refs := value.Referrers()
+	if refs == nil {
+		return 0, false
+	}
+
+	for _, ref := range *refs {
+		call, ok := ref.(*ssa.Call)
+		if !ok || len(call.Call.Args) == 0 || call.Call.Args[0] != value {
+			continue
+		}
+
+		if n, ok := bigIntSetter(call); ok {
+			return n, true
+		}
+	}
+
+	return 0, false
+}
+
+// bigIntSetter returns the constant value set by the given call to big.NewInt, or the
+// SetString, SetInt64, or SetUint64 methods of a big.Int.
+func bigIntSetter(call *ssa.Call) (int64, bool) {
+	var arg ssa.Value
+
+	switch call.Call.Value.String() {
+	case bigNewInt:
+		arg = call.Call.Args[0]
+	case bigIntSetInt64, bigIntSetUint64:
+		arg = call.Call.Args[1]
+	case bigIntSetString:
+		s, ok := call.Call.Args[1].(*ssa.Const)
+		if !ok || s.Value == nil || s.Value.Kind() != constant.String {
+			return 0, false
+		}
+
+		base, ok := call.Call.Args[2].(*ssa.Const)
+		if !ok {
+			return 0, false
+		}
+
+		n, err := strconv.ParseInt(constant.StringVal(s.Value), int(base.Int64()), 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+
+	c, ok := arg.(*ssa.Const)
+	if !ok || c.Value == nil {
+		return 0, false
+	}
+	return c.Int64(), true
+}
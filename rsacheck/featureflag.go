@@ -0,0 +1,123 @@
+package rsacheck
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkFeatureFlagBits checks if the number of bits, selected by a branch on a boolean
+// feature flag, is weak on the path taken by default, such as bits = 1024 when legacyMode,
+// declared as var legacyMode = true, is set. It reports whether a finding was reported.
+//
+// Feature flags must be package-level boolean variables with a constant initializer, which
+// is the default even if they're changed at runtime, such as by a command-line flag.
+func checkFeatureFlagBits(pass *analysis.Pass, instr *ssa.Call, bits *ssa.Phi) bool {
+	minBits := configOf(pass).MinBits
+
+	for i, edge := range bits.Edges {
+		n, weak := weakBits(edge, minBits)
+		if !weak || n <= 0 {
+			continue
+		}
+
+		flag, value, ok := defaultBranch(bits.Block(), bits.Block().Preds[i])
+		if !ok {
+			continue
+		}
+
+		report(pass, featureFlagBitsMessage, analysis.Diagnostic{
+			Pos:     instr.Pos(),
+			Message: fmt.Sprintf(featureFlagBitsMessage, n, flag.Name(), value, minBits),
+			Related: []analysis.RelatedInformation{
+				{Pos: flag.Pos(), Message: fmt.Sprintf("%v defaults to %v here", flag.Name(), value)},
+			},
+		})
+		return true
+	}
+
+	return false
+}
+
+// defaultBranch reports whether the edge from the predecessor to the given block is taken
+// by default, since it's only reached by the branch on a feature flag that's taken with
+// the flag's initial value. It returns the feature flag, and its initial value.
+func defaultBranch(block, pred *ssa.BasicBlock) (*ssa.Global, bool, bool) {
+	// Follow blocks with a single predecessor up to the branch.
+	for range 8 {
+		if branch, ok := pred.Instrs[len(pred.Instrs)-1].(*ssa.If); ok {
+			flag, value, ok := featureFlag(branch.Cond)
+			if !ok {
+				return nil, false, false
+			}
+
+			// The first successor is taken if the condition is true.
+			taken := pred.Succs[0]
+			if !value {
+				taken = pred.Succs[1]
+			}
+			if taken != block {
+				return nil, false, false
+			}
+
+			initial, _ := initialValue(flag)
+			return flag, constant.BoolVal(initial.Value), true
+		}
+
+		if len(pred.Preds) != 1 {
+			break
+		}
+		block, pred = pred, pred.Preds[0]
+	}
+
+	return nil, false, false
+}
+
+// featureFlag returns the feature flag the condition loads, and the value of the condition
+// with the flag's initial value, following negations such as !legacyMode.
+func featureFlag(cond ssa.Value) (*ssa.Global, bool, bool) {
+	load, ok := cond.(*ssa.UnOp)
+	if !ok {
+		return nil, false, false
+	}
+
+	if load.Op == token.NOT {
+		flag, value, ok := featureFlag(load.X)
+		return flag, !value, ok
+	}
+
+	global, ok := load.X.(*ssa.Global)
+	if load.Op != token.MUL || !ok {
+		return nil, false, false
+	}
+
+	initial, ok := initialValue(global)
+	if !ok || initial.Value == nil || initial.Value.Kind() != constant.Bool {
+		return nil, false, false
+	}
+
+	return global, constant.BoolVal(initial.Value), true
+}
+
+// initialValue returns the constant a package-level variable is initialized with by its
+// package initializer, regardless of whether it's reassigned later.
+func initialValue(global *ssa.Global) (*ssa.Const, bool) {
+	init, ok := global.Pkg.Members["init"].(*ssa.Function)
+	if !ok {
+		return nil, false
+	}
+
+	for _, b := range init.Blocks {
+		for _, instr := range b.Instrs {
+			if store, ok := instr.(*ssa.Store); ok && store.Addr == global {
+				c, ok := store.Val.(*ssa.Const)
+				return c, ok
+			}
+		}
+	}
+
+	return nil, false
+}
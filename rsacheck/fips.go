@@ -0,0 +1,86 @@
+package rsacheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// fipsTags are build tags that select the BoringCrypto FIPS module.
+var fipsTags = map[string]bool{
+	"boringcrypto":              true,
+	"goexperiment.boringcrypto": true,
+}
+
+// fipsMode reports whether the package being analyzed is meant to run in FIPS 140 mode,
+// either because one of its files is only built with BoringCrypto (//go:build boringcrypto),
+// or because it enables the native FIPS 140 module (//go:debug fips140=on).
+func fipsMode(pass *analysis.Pass) bool {
+	for _, file := range pass.Files {
+		if fipsFile(file) {
+			return true
+		}
+	}
+	return false
+}
+
+// fipsFile reports whether the given file selects FIPS 140 mode.
+func fipsFile(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+
+		for _, comment := range group.List {
+			if value, ok := strings.CutPrefix(comment.Text, "//go:debug fips140="); ok {
+				return value == "on" || value == "only"
+			}
+
+			expr, err := constraint.Parse(comment.Text)
+			if err != nil {
+				continue
+			}
+
+			if requiresTags(expr, fipsTags) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requiresTags reports whether the build constraint requires one of the given tags:
+// the file is built when all tags are set, but not when the given tags aren't set.
+func requiresTags(expr constraint.Expr, tags map[string]bool) bool {
+	all := expr.Eval(func(string) bool { return true })
+	without := expr.Eval(func(tag string) bool { return !tags[tag] })
+	return all && !without
+}
+
+// checkFIPSKey checks if key generation parameters would be rejected in FIPS 140 mode,
+// where only two-prime keys of at least 2048 bits (and a multiple of 8) are approved.
+func checkFIPSKey(pass *analysis.Pass, instr *ssa.Call, nprimes, bits ssa.Value) {
+	if !fipsMode(pass) {
+		return
+	}
+
+	if nprimes, ok := nprimes.(*ssa.Const); ok && nprimes.Int64() != 2 {
+		reportf(pass, instr.Pos(), fipsMessage, fmt.Sprintf("a %v-prime RSA key", nprimes.Int64()))
+	}
+
+	if bits, ok := bits.(*ssa.Const); ok && (bits.Int64() < 2048 || bits.Int64()%8 != 0) {
+		reportf(pass, instr.Pos(), fipsMessage, fmt.Sprintf("a %v-bit RSA key", bits.Int64()))
+	}
+}
+
+// checkFIPSEncryption checks if PKCS #1 v1.5 encryption is used in FIPS 140 mode,
+// where it isn't an approved scheme.
+func checkFIPSEncryption(pass *analysis.Pass, instr *ssa.Call) {
+	if fipsMode(pass) {
+		reportf(pass, instr.Pos(), fipsMessage, "rsa.EncryptPKCS1v15")
+	}
+}
@@ -0,0 +1,166 @@
+package rsacheck
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// encryptOAEPFix returns a fix that replaces the given call to [crypto/rsa.EncryptPKCS1v15]
+// with a call to [crypto/rsa.EncryptOAEP] using SHA-256, keeping the random reader, public
+// key, and message arguments, and importing crypto/sha256 if needed. Ciphertexts must then
+// be decrypted using [crypto/rsa.DecryptOAEP].
+func encryptOAEPFix(pass *analysis.Pass, instr *ssa.Call) []analysis.SuggestedFix {
+	call, ok := callExpr(pass, instr)
+	if !ok || len(call.Args) != 3 || call.Ellipsis.IsValid() {
+		return nil
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !selectsFunc(pass, sel, encryptPKCS1v15) {
+		return nil
+	}
+
+	file, ok := fileOf(pass, call.Pos())
+	if !ok {
+		return nil
+	}
+
+	sha256, edits := addImport(file, "crypto/sha256")
+
+	// Arguments on their own lines stay on their own lines.
+	sep := ", "
+	if pass.Fset.Position(call.Args[0].Pos()).Line != pass.Fset.Position(call.Lparen).Line {
+		sep = ",\n" + indentation(pass, call.Args[0].Pos())
+	}
+
+	edits = append(edits,
+		analysis.TextEdit{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte("EncryptOAEP")},
+		analysis.TextEdit{Pos: call.Args[0].Pos(), End: call.Args[0].Pos(), NewText: []byte(sha256 + ".New()" + sep)},
+		analysis.TextEdit{Pos: call.Args[2].End(), End: call.Args[2].End(), NewText: []byte(sep + "nil")},
+	)
+
+	return []analysis.SuggestedFix{{
+		Message:   "Use rsa.EncryptOAEP with SHA-256 (decrypt with rsa.DecryptOAEP)",
+		TextEdits: edits,
+	}}
+}
+
+// selectsFunc reports whether the selector refers to the named function, such as
+// rsa.GenerateKey, rather than a variable holding it, which can't be fixed in place.
+func selectsFunc(pass *analysis.Pass, sel *ast.SelectorExpr, name string) bool {
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	return ok && fn.FullName() == name
+}
+
+// indentation returns the whitespace at the start of the line of the given position.
+func indentation(pass *analysis.Pass, pos token.Pos) string {
+	posn := pass.Fset.Position(pos)
+
+	content, err := pass.ReadFile(posn.Filename)
+	if err != nil || posn.Offset > len(content) {
+		return "\t"
+	}
+
+	line := content[posn.Offset-posn.Column+1 : posn.Offset]
+	return string(line[:len(line)-len(bytes.TrimLeft(line, " \t"))])
+}
+
+// addImport returns the name the package with the given path is imported as in the file,
+// and if it isn't imported, the edits that import it, keeping the imports sorted.
+func addImport(file *ast.File, importPath string) (string, []analysis.TextEdit) {
+	name := path.Base(importPath)
+
+	for _, spec := range file.Imports {
+		if p, _ := strconv.Unquote(spec.Path.Value); p != importPath {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name, nil
+		}
+		return name, nil
+	}
+
+	quoted := strconv.Quote(importPath)
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		if !gen.Lparen.IsValid() {
+			return name, []analysis.TextEdit{{Pos: gen.End(), End: gen.End(), NewText: []byte("\nimport " + quoted)}}
+		}
+
+		for _, spec := range gen.Specs {
+			if p, _ := strconv.Unquote(spec.(*ast.ImportSpec).Path.Value); p > importPath {
+				return name, []analysis.TextEdit{{Pos: spec.Pos(), End: spec.Pos(), NewText: []byte(quoted + "\n\t")}}
+			}
+		}
+
+		return name, []analysis.TextEdit{{Pos: gen.Rparen, End: gen.Rparen, NewText: []byte("\t" + quoted + "\n")}}
+	}
+
+	return name, []analysis.TextEdit{{Pos: file.Name.End(), End: file.Name.End(), NewText: []byte("\n\nimport " + quoted)}}
+}
+
+// generateKeyFix returns a fix that replaces the given call to
+// [crypto/rsa.GenerateMultiPrimeKey] with a call to [crypto/rsa.GenerateKey], dropping
+// the number of primes. The fix is only returned for two primes, where both generate
+// equivalent keys.
+func generateKeyFix(pass *analysis.Pass, instr *ssa.Call) []analysis.SuggestedFix {
+	if n, ok := instr.Call.Args[1].(*ssa.Const); !ok || n.Value == nil || n.Int64() != 2 {
+		return nil
+	}
+
+	call, ok := callExpr(pass, instr)
+	if !ok || len(call.Args) != 3 || call.Ellipsis.IsValid() {
+		return nil
+	}
+
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	if !selectsFunc(pass, sel, generateMultiPrimeKey) {
+		return nil
+	}
+
+	return []analysis.SuggestedFix{{
+		Message: "Use rsa.GenerateKey",
+		TextEdits: []analysis.TextEdit{
+			{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte("GenerateKey")},
+			{Pos: call.Args[1].Pos(), End: call.Args[2].Pos()},
+		},
+	}}
+}
+
+// bitsFix returns a fix that replaces the number of bits passed to the given call with
+// the minimum number of bits, if it's an integer literal, such as 1024, and not a named
+// constant or an expression.
+func bitsFix(pass *analysis.Pass, instr *ssa.Call) []analysis.SuggestedFix {
+	call, ok := callExpr(pass, instr)
+	if !ok || len(call.Args) == 0 {
+		return nil
+	}
+
+	lit, ok := ast.Unparen(call.Args[len(call.Args)-1]).(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return nil
+	}
+
+	bits := strconv.Itoa(configOf(pass).MinBits)
+
+	return []analysis.SuggestedFix{{
+		Message:   "Use " + bits + " bits",
+		TextEdits: []analysis.TextEdit{{Pos: lit.Pos(), End: lit.End(), NewText: []byte(bits)}},
+	}}
+}
@@ -0,0 +1,11 @@
+package rsacheck
+
+import "embed"
+
+// Fixtures contains the source of packages used to test the analyzer with its default
+// flags, under the "testdata/src" directory. Each line that the analyzer should report
+// has a "// want" comment, with the expected messages as quoted regular expressions,
+// following the conventions of the [golang.org/x/tools/go/analysis/analysistest] package.
+//
+//go:embed testdata/src/vulnerable testdata/src/not-vulnerable
+var Fixtures embed.FS
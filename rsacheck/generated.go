@@ -0,0 +1,94 @@
+package rsacheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkGeneratedBits checks if the number of bits is a package-level constant or variable
+// declared in a generated file, such as a const KeyBits written by a go:generate tool.
+// The value is resolved like any other, but the key size is then decided by the inputs
+// of the generator, which are harder to audit than the code using it.
+func checkGeneratedBits(pass *analysis.Pass, instr *ssa.Call, bits ssa.Value) {
+	arg, ok := callArg(pass, instr, bits)
+	if !ok {
+		return
+	}
+
+	var ident *ast.Ident
+	switch arg := astutil.Unparen(arg).(type) {
+	case *ast.Ident:
+		ident = arg
+	case *ast.SelectorExpr:
+		ident = arg.Sel
+	default:
+		return
+	}
+
+	obj := pass.TypesInfo.Uses[ident]
+	switch obj.(type) {
+	case *types.Const, *types.Var:
+	default:
+		return
+	}
+
+	if obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+		return
+	}
+
+	filename, ok := generatedFile(pass, obj.Pos())
+	if !ok {
+		return
+	}
+
+	report(pass, generatedBitsMessage, analysis.Diagnostic{
+		Pos:     instr.Pos(),
+		Message: fmt.Sprintf(generatedBitsMessage, obj.Name(), filepath.Base(filename)),
+		Related: []analysis.RelatedInformation{
+			{Pos: obj.Pos(), Message: obj.Name() + " is declared here"},
+		},
+	})
+}
+
+// generatedFile returns the name of the file at the given position, if it's a generated
+// file. Files of other packages are parsed up to their package clause, since their syntax
+// isn't available to the pass.
+func generatedFile(pass *analysis.Pass, pos token.Pos) (string, bool) {
+	if file, ok := fileOf(pass, pos); ok {
+		return pass.Fset.File(pos).Name(), ast.IsGenerated(file)
+	}
+
+	tf := pass.Fset.File(pos)
+	if tf == nil {
+		return "", false
+	}
+
+	content, err := os.ReadFile(tf.Name())
+	if err != nil {
+		return "", false
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), tf.Name(), content, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return "", false
+	}
+
+	return tf.Name(), ast.IsGenerated(file)
+}
+
+// inGeneratedFile reports whether the given position is in a generated file of the
+// package, which has a "// Code generated ... DO NOT EDIT." comment before its package
+// clause, such as protobuf or mock code, and isn't reported unless -lint-generated is set.
+func inGeneratedFile(pass *analysis.Pass, pos token.Pos) bool {
+	file, ok := fileOf(pass, pos)
+	return ok && ast.IsGenerated(file)
+}
@@ -0,0 +1,60 @@
+package rsacheck
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// gobEncode is the method used to serialize values with encoding/gob.
+const gobEncode = "(*encoding/gob.Encoder).Encode"
+
+// checkGobPrivateKey checks if an RSA private key, or a value containing one, is
+// serialized using [encoding/gob], if enabled. Gob persists every exported field,
+// including the private exponent and primes, without any protection, which is often
+// unintended when the surrounding struct is cached or sent to another service.
+func checkGobPrivateKey(pass *analysis.Pass, instr *ssa.Call) {
+	if !configOf(pass).GobPrivateKey {
+		return
+	}
+
+	value := unwrapInterface(instr.Call.Args[1])
+
+	if containsPrivateKey(value.Type(), map[types.Type]bool{}) {
+		reportf(pass, instr.Pos(), gobPrivateKeyMessage, value.Type())
+	}
+}
+
+// containsPrivateKey reports whether the given type is an [crypto/rsa.PrivateKey], or
+// a pointer, slice, array, map, or struct with exported fields that contains one.
+func containsPrivateKey(typ types.Type, seen map[types.Type]bool) bool {
+	if isRSAType(typ, "PrivateKey") {
+		return true
+	}
+
+	if seen[typ] {
+		return false
+	}
+	seen[typ] = true
+
+	switch typ := typ.Underlying().(type) {
+	case *types.Pointer:
+		return containsPrivateKey(typ.Elem(), seen)
+	case *types.Slice:
+		return containsPrivateKey(typ.Elem(), seen)
+	case *types.Array:
+		return containsPrivateKey(typ.Elem(), seen)
+	case *types.Map:
+		return containsPrivateKey(typ.Elem(), seen)
+	case *types.Struct:
+		for i := range typ.NumFields() {
+			// Unexported fields aren't encoded by gob.
+			if field := typ.Field(i); field.Exported() && containsPrivateKey(field.Type(), seen) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
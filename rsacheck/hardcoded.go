@@ -0,0 +1,102 @@
+package rsacheck
+
+import (
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// Functions used to compare, and create, big integers.
+const (
+	bigIntCmp       = "(*math/big.Int).Cmp"
+	bigIntString    = "(*math/big.Int).String"
+	bigIntSetString = "(*math/big.Int).SetString"
+	bigNewInt       = "math/big.NewInt"
+)
+
+// checkHardcodedKeyCompare checks if the modulus or private exponent of an RSA key is
+// compared to a hardcoded value, either using big.Int.Cmp with a constant big.Int, or
+// by comparing its string representation to a constant string. Such comparisons often
+// indicate an embedded test key, or a backdoor, on a production path.
+func checkHardcodedKeyCompare(pass *analysis.Pass, instr ssa.Instruction) {
+	if !configOf(pass).HardcodedKeyCompare {
+		return
+	}
+
+	switch instr := instr.(type) {
+	case *ssa.Call:
+		x, y := instr.Call.Args[0], instr.Call.Args[1]
+		if !constBigInt(y) {
+			x, y = y, x
+		}
+
+		if field, ok := keyField(x); ok && constBigInt(y) {
+			reportf(pass, instr.Pos(), hardcodedKeyCompareMessage, field)
+		}
+	case *ssa.BinOp:
+		if instr.Op != token.EQL && instr.Op != token.NEQ {
+			return
+		}
+
+		x, y := instr.X, instr.Y
+		if _, ok := x.(*ssa.Const); ok {
+			x, y = y, x
+		}
+
+		if _, ok := y.(*ssa.Const); !ok {
+			return
+		}
+
+		call, ok := callTo(x, bigIntString)
+		if !ok {
+			return
+		}
+
+		if field, ok := keyField(call.Call.Args[0]); ok {
+			reportf(pass, instr.Pos(), hardcodedKeyCompareMessage, field)
+		}
+	}
+}
+
+// keyField returns the name of the RSA key field that the given value is loaded from,
+// if it's the modulus (N) or private exponent (D).
+func keyField(value ssa.Value) (string, bool) {
+	field, ok := loadedField(value)
+	if !ok {
+		return "", false
+	}
+
+	switch name := fieldName(field); {
+	case name == "N" && isRSAType(field.X.Type(), "PublicKey"):
+		return "modulus", true
+	case name == "D" && isRSAType(field.X.Type(), "PrivateKey"):
+		return "private exponent", true
+	}
+
+	return "", false
+}
+
+// constBigInt reports whether the given value is a big.Int created from a constant,
+// using big.NewInt or big.Int.SetString.
+func constBigInt(value ssa.Value) bool {
+	if extract, ok := value.(*ssa.Extract); ok && extract.Index == 0 {
+		value = extract.Tuple
+	}
+
+	call, ok := value.(*ssa.Call)
+	if !ok {
+		return false
+	}
+
+	switch call.Call.Value.String() {
+	case bigNewInt:
+		_, ok := call.Call.Args[0].(*ssa.Const)
+		return ok
+	case bigIntSetString:
+		_, ok := call.Call.Args[1].(*ssa.Const)
+		return ok
+	}
+
+	return false
+}
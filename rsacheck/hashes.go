@@ -0,0 +1,159 @@
+package rsacheck
+
+import (
+	"crypto"
+	"fmt"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// weakHashes are crypto.Hash values that aren't collision resistant.
+var weakHashes = map[crypto.Hash]bool{
+	crypto.MD4:  true,
+	crypto.MD5:  true,
+	crypto.SHA1: true,
+}
+
+// hashFunctions are functions of the standard library returning a hash.Hash, with the
+// crypto.Hash it computes, which gives its name and the size of its digests.
+var hashFunctions = map[string]crypto.Hash{
+	"crypto/md5.New":       crypto.MD5,
+	"crypto/sha1.New":      crypto.SHA1,
+	"crypto/sha256.New224": crypto.SHA224,
+	"crypto/sha256.New":    crypto.SHA256,
+	"crypto/sha512.New384": crypto.SHA384,
+	"crypto/sha512.New":    crypto.SHA512,
+}
+
+// maxHash is the largest known crypto.Hash value. Size panics for larger values.
+const maxHash = crypto.BLAKE2b_512
+
+// digestSize reports whether n is the size in bytes of the digests of a known hash.
+func digestSize(n int) bool {
+	for h := crypto.MD4; h <= maxHash; h++ {
+		if h.Size() == n {
+			return true
+		}
+	}
+	return false
+}
+
+// checkVariableHash checks if the hash given to a signing or encryption function is a
+// variable that may be a weak hash, such as SHA-1 in a branch for legacy clients. The
+// value is resolved conservatively: the finding is reported if any of its possible
+// values is weak.
+func checkVariableHash(pass *analysis.Pass, instr *ssa.Call, hash ssa.Value) {
+	if _, ok := unwrapInterface(hash).(*ssa.Phi); !ok {
+		return
+	}
+
+	for _, value := range possibleValues(unwrapInterface(hash)) {
+		if name, ok := weakHash(value); ok {
+			reportf(pass, instr.Pos(), variableHashMessage, name)
+			return
+		}
+	}
+}
+
+// checkOAEPHash checks if the hash given to [crypto/rsa.EncryptOAEP] or
+// [crypto/rsa.DecryptOAEP] is created by a function of a weak hash, such as sha1.New.
+// Hash variables that may be weak are reported by [checkVariableHash] instead.
+func checkOAEPHash(pass *analysis.Pass, instr *ssa.Call, hash ssa.Value) {
+	if _, ok := unwrapInterface(hash).(*ssa.Call); !ok {
+		return
+	}
+
+	if name, ok := weakHash(hash); ok {
+		reportf(pass, instr.Pos(), oaepHashMessage, name)
+	}
+}
+
+// weakHash returns the name of the hash, if the given value is a constant crypto.Hash,
+// or a hash.Hash created by a function, that isn't collision resistant.
+func weakHash(value ssa.Value) (string, bool) {
+	switch value := unwrapInterface(value).(type) {
+	case *ssa.Const:
+		if value.Value == nil || !isType(value.Type(), "crypto", "Hash") {
+			return "", false
+		}
+
+		hash := crypto.Hash(value.Int64())
+		return hash.String(), weakHashes[hash]
+	case *ssa.Call:
+		hash, ok := hashFunctions[calleeName(value)]
+		return hash.String(), ok && weakHashes[hash]
+	}
+
+	return "", false
+}
+
+// nonCryptoHashes are packages of hash functions that aren't cryptographic, and must
+// never be used to compute a digest that's signed.
+var nonCryptoHashes = map[string]bool{
+	"hash/adler32": true,
+	"hash/crc32":   true,
+	"hash/crc64":   true,
+	"hash/fnv":     true,
+	"hash/maphash": true,
+}
+
+// checkSignedDigest checks if the digest given to a signing function is computed using
+// a non-cryptographic hash, such as FNV or CRC-32, whose collisions are trivial to find.
+func checkSignedDigest(pass *analysis.Pass, instr *ssa.Call, digest ssa.Value) {
+	hash, ok := digestHash(digest)
+	if !ok {
+		return
+	}
+
+	path := calleePackage(hash)
+
+	report(pass, nonCryptoDigestMessage, analysis.Diagnostic{
+		Pos:     instr.Pos(),
+		Message: fmt.Sprintf(nonCryptoDigestMessage, path),
+		Related: []analysis.RelatedInformation{
+			{Pos: hash.Pos(), Message: fmt.Sprintf("%v hash is created here", path)},
+		},
+	})
+}
+
+// digestHash returns the call that created a non-cryptographic hash, if the given digest
+// is the result of calling Sum on it, such as fnv.New64a().Sum(nil).
+func digestHash(digest ssa.Value) (*ssa.Call, bool) {
+	for {
+		switch value := digest.(type) {
+		case *ssa.Slice:
+			digest = value.X
+			continue
+		case *ssa.ChangeType:
+			digest = value.X
+			continue
+		case *ssa.Convert:
+			digest = value.X
+			continue
+		}
+		break
+	}
+
+	sum, ok := digest.(*ssa.Call)
+	if !ok {
+		return nil, false
+	}
+
+	var recv ssa.Value
+	switch {
+	case sum.Call.IsInvoke() && sum.Call.Method.Name() == "Sum":
+		recv = sum.Call.Value
+	case sum.Call.StaticCallee() != nil && sum.Call.StaticCallee().Name() == "Sum" && len(sum.Call.Args) > 0:
+		recv = sum.Call.Args[0]
+	default:
+		return nil, false
+	}
+
+	hash, ok := unwrapInterface(recv).(*ssa.Call)
+	if !ok || !nonCryptoHashes[calleePackage(hash)] {
+		return nil, false
+	}
+
+	return hash, true
+}
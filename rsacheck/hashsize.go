@@ -0,0 +1,104 @@
+package rsacheck
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkHashSizeBits checks if the number of bits is the size of a hash in bytes, such as
+// sha256.Size or h.Size(), which is a likely mistake that results in a tiny key.
+//
+// It reports whether the finding was reported.
+func checkHashSizeBits(pass *analysis.Pass, instr *ssa.Call, bits ssa.Value) bool {
+	name, ok := hashSize(pass, instr, bits)
+	if !ok {
+		return false
+	}
+
+	reportf(pass, instr.Pos(), hashSizeBitsMessage, name, configOf(pass).MinBits)
+	return true
+}
+
+// hashSize returns the name of the hash size used as the given number of bits, if any.
+//
+// Hash size constants are folded by the type checker, so they're found in the syntax of
+// the call, while calls to the Size method of a [hash.Hash] or [crypto.Hash] are found
+// in its SSA form.
+func hashSize(pass *analysis.Pass, instr *ssa.Call, bits ssa.Value) (string, bool) {
+	if call, ok := bits.(*ssa.Call); ok {
+		switch {
+		case call.Call.Value.String() == cryptoHashSize:
+			return "crypto.Hash.Size()", true
+		case call.Call.IsInvoke() && call.Call.Method.Name() == "Size" && isType(call.Call.Value.Type(), "hash", "Hash"):
+			return "hash.Hash.Size()", true
+		}
+		return "", false
+	}
+
+	if _, ok := bits.(*ssa.Const); !ok {
+		return "", false
+	}
+
+	arg, ok := callArg(pass, instr, bits)
+	if !ok {
+		return "", false
+	}
+
+	sel, ok := astutil.Unparen(arg).(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+
+	obj, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Const)
+	if !ok || obj.Pkg() == nil || !strings.HasPrefix(obj.Name(), "Size") {
+		return "", false
+	}
+
+	switch path := obj.Pkg().Path(); path {
+	case "crypto/md5", "crypto/sha1", "crypto/sha256", "crypto/sha512", "crypto/sha3":
+		return obj.Pkg().Name() + "." + obj.Name(), true
+	}
+
+	return "", false
+}
+
+// callArg returns the syntax of the argument of the given call that has the given value.
+func callArg(pass *analysis.Pass, instr *ssa.Call, value ssa.Value) (ast.Expr, bool) {
+	index := -1
+	for i, arg := range instr.Call.Args {
+		if arg == value {
+			index = i
+		}
+	}
+
+	call, ok := callExpr(pass, instr)
+	if !ok || index < 0 || index >= len(call.Args) {
+		return nil, false
+	}
+
+	return call.Args[index], true
+}
+
+// callExpr returns the syntax of the given call, found using the position of its
+// opening parenthesis.
+func callExpr(pass *analysis.Pass, instr *ssa.Call) (*ast.CallExpr, bool) {
+	for _, file := range pass.Files {
+		if instr.Pos() < file.Pos() || instr.Pos() > file.End() {
+			continue
+		}
+
+		path, _ := astutil.PathEnclosingInterval(file, instr.Pos(), instr.Pos())
+		for _, node := range path {
+			if call, ok := node.(*ast.CallExpr); ok && call.Lparen == instr.Pos() {
+				return call, true
+			}
+		}
+	}
+
+	return nil, false
+}
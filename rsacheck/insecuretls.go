@@ -0,0 +1,78 @@
+package rsacheck
+
+import (
+	"go/constant"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkInsecureSkipVerify checks if the function sets the InsecureSkipVerify field of a
+// [crypto/tls.Config] to true, and also uses an RSA key, such as for a client certificate.
+// Skipping verification makes the strength of the key moot, since a peer can present any
+// certificate, so both are reported together.
+//
+// This is a heuristic: the TLS configuration and the key don't have to be related, and
+// only keys in the same function are considered.
+func checkInsecureSkipVerify(pass *analysis.Pass, fn *ssa.Function) {
+	if !configOf(pass).InsecureSkipVerify {
+		return
+	}
+
+	var (
+		skip *ssa.Store
+		key  token.Pos
+	)
+
+	for _, param := range fn.Params {
+		if !key.IsValid() && isRSAType(param.Type(), "PrivateKey") {
+			key = param.Pos()
+		}
+	}
+
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			switch instr := instr.(type) {
+			case *ssa.Store:
+				if skip == nil && skipsVerify(instr) {
+					skip = instr
+				}
+			case *ssa.Extract:
+				// Keys returned by a call, such as rsa.GenerateKey, are used where
+				// they're returned.
+				if !key.IsValid() && isRSAType(instr.Type(), "PrivateKey") {
+					key = instr.Tuple.Pos()
+				}
+			case ssa.Value:
+				if !key.IsValid() && isRSAType(instr.Type(), "PrivateKey") {
+					key = instr.Pos()
+				}
+			}
+		}
+	}
+
+	if skip == nil || !key.IsValid() {
+		return
+	}
+
+	report(pass, insecureSkipVerifyMessage, analysis.Diagnostic{
+		Pos:     skip.Pos(),
+		Message: insecureSkipVerifyMessage,
+		Related: []analysis.RelatedInformation{
+			{Pos: key, Message: "RSA key is used here"},
+		},
+	})
+}
+
+// skipsVerify reports whether the store sets the InsecureSkipVerify field of a
+// [crypto/tls.Config] to true.
+func skipsVerify(store *ssa.Store) bool {
+	addr, ok := store.Addr.(*ssa.FieldAddr)
+	if !ok || fieldName(addr) != "InsecureSkipVerify" || !isType(addr.X.Type(), "crypto/tls", "Config") {
+		return false
+	}
+
+	c, ok := store.Val.(*ssa.Const)
+	return ok && c.Value != nil && c.Value.Kind() == constant.Bool && constant.BoolVal(c.Value)
+}
@@ -0,0 +1,128 @@
+package rsacheck
+
+import (
+	"go/constant"
+	"math/big"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+const bigIntSetBytes = "(*math/big.Int).SetBytes"
+
+// checkKeyLiteral checks RSA keys constructed from struct literals, such as
+// &rsa.PublicKey{N: n, E: 65537}, since their size isn't checked by the "crypto/rsa"
+// package until they're used. If the bit length of the modulus can be inferred from a
+// constant, such as big.NewInt(3233), or new(big.Int).SetString("c3...", 16), weak keys
+// are reported. Otherwise, the key is reported unless its size is validated in the same
+// function, using its Size method or N.BitLen().
+func checkKeyLiteral(pass *analysis.Pass, alloc *ssa.Alloc) {
+	if alloc.Comment != "complit" {
+		return
+	}
+
+	var n ssa.Value
+
+	switch {
+	case isRSAType(alloc.Type(), "PublicKey"):
+		n = storedField(alloc, "N")
+	case isRSAType(alloc.Type(), "PrivateKey"):
+		n = storedField(alloc, "PublicKey", "N")
+	}
+
+	if n == nil {
+		return
+	}
+
+	minBits := configOf(pass).MinBits
+
+	if bits, ok := modulusBits(n); ok {
+		if bits < minBits {
+			reportf(pass, alloc.Pos(), keyLiteralBitsMessage, bits, minBits)
+		}
+		return
+	}
+
+	if len(keyCalls(alloc, publicKeySize, bigIntBitLen)) == 0 {
+		reportf(pass, alloc.Pos(), keyLiteralMessage, minBits)
+	}
+}
+
+// storedField returns the value stored to the field at the given path of the struct
+// allocated by alloc, such as PublicKey.N, or nil if there isn't exactly one.
+func storedField(alloc ssa.Value, path ...string) ssa.Value {
+	var value ssa.Value
+
+	for _, ref := range *alloc.Referrers() {
+		addr, ok := ref.(*ssa.FieldAddr)
+		if !ok || addr.X != alloc || fieldName(addr) != path[0] {
+			continue
+		}
+
+		if len(path) > 1 {
+			if v := storedField(addr, path[1:]...); v != nil {
+				value = v
+			}
+			continue
+		}
+
+		for _, ref := range *addr.Referrers() {
+			if store, ok := ref.(*ssa.Store); ok && store.Addr == addr {
+				if value != nil {
+					return nil
+				}
+				value = store.Val
+			}
+		}
+	}
+
+	return value
+}
+
+// modulusBits returns the bit length of a modulus created from a constant.
+func modulusBits(n ssa.Value) (int, bool) {
+	call, ok := callTo(n, bigNewInt, bigIntSetString, bigIntSetBytes)
+	if !ok {
+		return 0, false
+	}
+
+	args := call.Call.Args
+
+	switch call.Call.Value.String() {
+	case bigNewInt:
+		x, ok := constBits(args[0])
+		if !ok {
+			return 0, false
+		}
+		return big.NewInt(x).BitLen(), true
+	case bigIntSetString:
+		s, ok := args[1].(*ssa.Const)
+		if !ok || s.Value == nil || s.Value.Kind() != constant.String {
+			return 0, false
+		}
+
+		base, ok := constBits(args[2])
+		if !ok {
+			return 0, false
+		}
+
+		x, ok := new(big.Int).SetString(constant.StringVal(s.Value), int(base))
+		if !ok {
+			return 0, false
+		}
+		return x.BitLen(), true
+	case bigIntSetBytes:
+		conv, ok := args[1].(*ssa.Convert)
+		if !ok {
+			return 0, false
+		}
+
+		s, ok := conv.X.(*ssa.Const)
+		if !ok || s.Value == nil || s.Value.Kind() != constant.String {
+			return 0, false
+		}
+		return new(big.Int).SetBytes([]byte(constant.StringVal(s.Value))).BitLen(), true
+	}
+
+	return 0, false
+}
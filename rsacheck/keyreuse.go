@@ -0,0 +1,146 @@
+package rsacheck
+
+import (
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// keyPurposes are the signing and decryption calls a private key is passed to.
+type keyPurposes struct {
+	// pos is the position of the key's definition.
+	pos token.Pos
+
+	sign, decrypt *ssa.Call
+}
+
+// signFuncs and decryptFuncs are the RSA functions that sign and decrypt using a
+// private key.
+var (
+	signFuncs    = []string{signPKCS1v15, signPSS}
+	decryptFuncs = []string{decryptPKCS1v15, decryptOAEP}
+)
+
+// checkKeyReuse checks if the same private key is used both for signing and for
+// decryption by the given functions of the package, which is discouraged, since a
+// weakness of either scheme, or an oracle exposed by one, can then be used to attack the
+// other. Uses are collected across the package, and each key is reported once, at its
+// definition.
+//
+// Keys are identified by the package-level variable or struct field that holds them,
+// the call to [crypto/rsa.GenerateKey] that generates them, or the function parameter
+// they're passed as.
+func checkKeyReuse(pass *analysis.Pass, funcs []*ssa.Function) {
+	uses, order := keyUsage(funcs)
+
+	for _, key := range order {
+		u := uses[key]
+		if u.sign == nil || u.decrypt == nil {
+			continue
+		}
+
+		// Keys defined in other packages, such as fields of their types, are reported
+		// by the analysis of those packages.
+		if _, ok := fileOf(pass, u.pos); !ok {
+			continue
+		}
+
+		report(pass, keyReuseMessage, analysis.Diagnostic{
+			Pos:     u.pos,
+			Message: keyReuseMessage,
+			Related: []analysis.RelatedInformation{
+				{Pos: u.sign.Pos(), Message: "key is used for signing here"},
+				{Pos: u.decrypt.Pos(), Message: "and for decryption here"},
+			},
+		})
+	}
+}
+
+// keyUsage returns the signing and decryption calls each private key is passed to by
+// the given functions, and the keys in the order they're first used. Keys are identified
+// by [keyOrigin].
+func keyUsage(funcs []*ssa.Function) (map[any]*keyPurposes, []any) {
+	uses := map[any]*keyPurposes{}
+	var order []any
+
+	for _, fn := range funcs {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				call, ok := instr.(*ssa.Call)
+				if !ok {
+					continue
+				}
+
+				name := checkedCallee(call)
+
+				sign, decrypt := slices.Contains(signFuncs, name), slices.Contains(decryptFuncs, name)
+				if !sign && !decrypt {
+					continue
+				}
+
+				key, pos, ok := keyOrigin(call.Call.Args[keyArgs[name].index])
+				if !ok {
+					continue
+				}
+
+				u, ok := uses[key]
+				if !ok {
+					u = &keyPurposes{pos: pos}
+					uses[key] = u
+					order = append(order, key)
+				}
+
+				if sign && u.sign == nil {
+					u.sign = call
+				}
+				if decrypt && u.decrypt == nil {
+					u.decrypt = call
+				}
+			}
+		}
+	}
+
+	return uses, order
+}
+
+// keyOrigin returns what identifies the given private key across the package, and the
+// position of its definition, following local variables to the value stored in them.
+func keyOrigin(value ssa.Value) (any, token.Pos, bool) {
+	switch value := storedValue(value).(type) {
+	case *ssa.UnOp:
+		if value.Op != token.MUL {
+			return nil, token.NoPos, false
+		}
+
+		switch x := value.X.(type) {
+		case *ssa.Global:
+			return x, x.Pos(), true
+		case *ssa.FieldAddr:
+			field := structField(x.X.Type(), x.Field)
+			return field, field.Pos(), true
+		}
+	case *ssa.Field:
+		field := structField(value.X.Type(), value.Field)
+		return field, field.Pos(), true
+	case *ssa.Extract:
+		if call, ok := callTo(value, generateKey, generateMultiPrimeKey); ok {
+			return call, call.Pos(), true
+		}
+	case *ssa.Parameter:
+		return value, value.Pos(), true
+	}
+
+	return nil, token.NoPos, false
+}
+
+// structField returns the field with the given index of the struct type, or of the
+// struct type pointed to.
+func structField(typ types.Type, index int) *types.Var {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	return typ.Underlying().(*types.Struct).Field(index)
+}
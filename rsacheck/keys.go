@@ -0,0 +1,239 @@
+package rsacheck
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// recommendedExponent is the public exponent used by [crypto/rsa.GenerateKey].
+const recommendedExponent = 65537
+
+// isRSAType reports whether the given type is, or points to, the named type from
+// the "crypto/rsa" package, such as "PublicKey" or "PrivateKey".
+func isRSAType(typ types.Type, name string) bool {
+	return isType(typ, "crypto/rsa", name)
+}
+
+// isType reports whether the given type, or the type it points to, is the named type
+// from the given package.
+func isType(typ types.Type, path, name string) bool {
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+
+	return named.Obj().Pkg().Path() == path && named.Obj().Name() == name
+}
+
+// fieldName returns the name of the struct field addressed by the given instruction.
+func fieldName(addr *ssa.FieldAddr) string {
+	ptr, ok := addr.X.Type().Underlying().(*types.Pointer)
+	if !ok {
+		return ""
+	}
+
+	st, ok := ptr.Elem().Underlying().(*types.Struct)
+	if !ok {
+		return ""
+	}
+
+	return st.Field(addr.Field).Name()
+}
+
+// publicExponentStore returns the constant public exponent stored by the given
+// instruction, if it assigns to the E field of an [crypto/rsa.PublicKey]. Exponents
+// converted from a big.Int holding a constant are resolved by [constExponent].
+func publicExponentStore(store *ssa.Store) (int64, *ssa.FieldAddr, bool) {
+	addr, ok := store.Addr.(*ssa.FieldAddr)
+	if !ok || fieldName(addr) != "E" || !isRSAType(addr.X.Type(), "PublicKey") {
+		return 0, nil, false
+	}
+
+	e, ok := constExponent(store.Val)
+	if !ok {
+		return 0, nil, false
+	}
+
+	return e, addr, true
+}
+
+// generatedKeyExponent returns the first small public exponent assigned to the
+// private key returned by the given key generation call (key.E = 3).
+func generatedKeyExponent(instr *ssa.Call) (int64, bool) {
+	for _, ref := range *instr.Referrers() {
+		key, ok := ref.(*ssa.Extract)
+		if !ok || key.Index != 0 {
+			continue
+		}
+
+		for _, ref := range *key.Referrers() {
+			pub, ok := ref.(*ssa.FieldAddr)
+			if !ok || fieldName(pub) != "PublicKey" {
+				continue
+			}
+
+			for _, ref := range *pub.Referrers() {
+				addr, ok := ref.(*ssa.FieldAddr)
+				if !ok {
+					continue
+				}
+
+				for _, ref := range *addr.Referrers() {
+					store, ok := ref.(*ssa.Store)
+					if !ok {
+						continue
+					}
+
+					if e, _, ok := publicExponentStore(store); ok && e < recommendedExponent {
+						return e, true
+					}
+				}
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// generatingCall returns the key generation call that produced the private key
+// whose public exponent is addressed by the given instruction, if any.
+func generatingCall(addr *ssa.FieldAddr) (*ssa.Call, bool) {
+	pub, ok := addr.X.(*ssa.FieldAddr)
+	if !ok || fieldName(pub) != "PublicKey" {
+		return nil, false
+	}
+
+	return callTo(pub.X, generateKey, generateMultiPrimeKey)
+}
+
+// checkPublicExponent checks if a small public exponent is assigned to an RSA public key.
+// Small exponents, such as 3, are vulnerable to several attacks when used without
+// proper padding, and there is no reason to use anything but 65537.
+//
+// Keys that are also generated with a weak number of bits are reported once, at the
+// key generation call, by [checkWeakKey].
+func checkPublicExponent(pass *analysis.Pass, store *ssa.Store) {
+	e, addr, ok := publicExponentStore(store)
+	if !ok || e >= recommendedExponent {
+		return
+	}
+
+	if call, ok := generatingCall(addr); ok {
+		if _, weak := weakBits(call.Call.Args[len(call.Call.Args)-1], configOf(pass).MinBits); weak {
+			return
+		}
+	}
+
+	reportf(pass, store.Pos(), smallExponentMessage, e)
+}
+
+// checkWeakKey checks if a key generated with a weak number of bits is also assigned
+// a small public exponent, which makes it broken beyond the two separate weaknesses.
+//
+// It reports whether the combined finding was reported, in which case the weak bits
+// should not be reported separately.
+func checkWeakKey(pass *analysis.Pass, instr *ssa.Call, bits ssa.Value) bool {
+	minBits := configOf(pass).MinBits
+
+	n, weak := weakBits(bits, minBits)
+	if !weak {
+		return false
+	}
+
+	e, ok := generatedKeyExponent(instr)
+	if !ok {
+		return false
+	}
+
+	reportf(pass, instr.Pos(), brokenKeyMessage, n, e, minBits)
+	return true
+}
+
+// checkDeepEqual checks if an RSA private key is compared using [reflect.DeepEqual], which
+// doesn't run in constant time, and may leak information about the secret key material.
+func checkDeepEqual(pass *analysis.Pass, instr *ssa.Call) {
+	if !configOf(pass).KeyDeepEqual {
+		return
+	}
+
+	for _, arg := range instr.Call.Args {
+		if isRSAType(unwrapInterface(arg).Type(), "PrivateKey") {
+			reportf(pass, instr.Pos(), deepEqualMessage)
+			return
+		}
+	}
+}
+
+// keyCalls returns the calls to the named functions or methods made with the given key,
+// or any of its fields (such as key.PublicKey, or key.N), as an argument or receiver.
+func keyCalls(key ssa.Value, names ...string) []*ssa.Call {
+	var (
+		calls []*ssa.Call
+		visit func(ssa.Value)
+		seen  = map[ssa.Value]bool{}
+	)
+
+	visit = func(value ssa.Value) {
+		if seen[value] || value.Referrers() == nil {
+			return
+		}
+		seen[value] = true
+
+		for _, ref := range *value.Referrers() {
+			switch ref := ref.(type) {
+			case *ssa.FieldAddr:
+				visit(ref)
+			case *ssa.UnOp:
+				visit(ref)
+			case *ssa.Call:
+				if call, ok := callTo(ref, names...); ok {
+					calls = append(calls, call)
+				}
+			}
+		}
+	}
+	visit(key)
+
+	return calls
+}
+
+// checkKeySizeValidated checks if a key generated with a non-constant number of bits has its
+// size validated in the same function, using key.Size() or key.N.BitLen(). Otherwise, the
+// key's strength depends entirely on where the number of bits comes from.
+func checkKeySizeValidated(pass *analysis.Pass, instr *ssa.Call, bits ssa.Value) {
+	if !configOf(pass).UnvalidatedKeySize {
+		return
+	}
+
+	if constantBits(bits) {
+		return
+	}
+
+	for _, ref := range *instr.Referrers() {
+		key, ok := ref.(*ssa.Extract)
+		if !ok || key.Index != 0 {
+			continue
+		}
+
+		if len(keyCalls(key, publicKeySize, bigIntBitLen)) == 0 {
+			reportf(pass, instr.Pos(), unvalidatedKeySizeMessage)
+		}
+	}
+}
+
+// constantBits reports whether every value the given number of bits may have is
+// constant, as resolved by [constBits], so that the size checks apply to it.
+func constantBits(bits ssa.Value) bool {
+	for _, value := range possibleValues(bits) {
+		if _, ok := constBits(value); !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package rsacheck
+
+import "golang.org/x/tools/go/ssa"
+
+// loop is a natural loop in a function's control flow graph.
+type loop struct {
+	header *ssa.BasicBlock
+	body   map[*ssa.BasicBlock]bool
+}
+
+// innermostLoop returns the innermost loop containing the given block, if any.
+//
+// Loops are found using their back edges: an edge from a block to one of its
+// dominators. The loop's body is every block that can reach the back edge without
+// passing through the loop's header.
+func innermostLoop(b *ssa.BasicBlock) (*loop, bool) {
+	var innermost *loop
+
+	for _, src := range b.Parent().Blocks {
+		for _, header := range src.Succs {
+			if !header.Dominates(src) {
+				continue
+			}
+
+			l := &loop{header: header, body: map[*ssa.BasicBlock]bool{header: true}}
+
+			stack := []*ssa.BasicBlock{src}
+			for len(stack) > 0 {
+				block := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+
+				if l.body[block] {
+					continue
+				}
+				l.body[block] = true
+
+				stack = append(stack, block.Preds...)
+			}
+
+			if l.body[b] && (innermost == nil || len(l.body) < len(innermost.body)) {
+				innermost = l
+			}
+		}
+	}
+
+	return innermost, innermost != nil
+}
+
+// bounded reports whether the loop's condition compares against a constant,
+// such as "for i := 0; i < 10; i++", or ranging over an array or integer.
+//
+// Loops over slices, maps, and channels, or with any other non-constant
+// condition, are considered unbounded.
+func (l *loop) bounded() bool {
+	cond, ok := l.header.Instrs[len(l.header.Instrs)-1].(*ssa.If)
+	if !ok {
+		return false
+	}
+
+	cmp, ok := cond.Cond.(*ssa.BinOp)
+	if !ok {
+		return false
+	}
+
+	_, x := cmp.X.(*ssa.Const)
+	_, y := cmp.Y.(*ssa.Const)
+
+	return x || y
+}
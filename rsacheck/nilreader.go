@@ -0,0 +1,45 @@
+package rsacheck
+
+import (
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkNilReader checks if a random reader parameter is passed to an RSA function
+// without handling nil, if enabled. APIs commonly document a nil reader as defaulting
+// to [crypto/rand.Reader], but unless the function falls back to it explicitly, with
+// "if r == nil { r = rand.Reader }", callers passing nil get a panic or, depending on
+// the Go version, a silently ignored reader. A reader that's compared to nil, such as
+// to return an error, is considered handled.
+func checkNilReader(pass *analysis.Pass, instr *ssa.Call, value ssa.Value) {
+	if !configOf(pass).NilReader {
+		return
+	}
+
+	param, ok := unwrapInterface(value).(*ssa.Parameter)
+	if !ok || comparedToNil(param) {
+		return
+	}
+
+	reportf(pass, instr.Pos(), nilReaderMessage, param.Name(), strings.TrimPrefix(calleeName(instr), "crypto/"))
+}
+
+// comparedToNil reports whether the given parameter is compared to nil.
+func comparedToNil(param *ssa.Parameter) bool {
+	for _, ref := range *param.Referrers() {
+		cmp, ok := ref.(*ssa.BinOp)
+		if !ok || (cmp.Op != token.EQL && cmp.Op != token.NEQ) {
+			continue
+		}
+
+		for _, operand := range []ssa.Value{cmp.X, cmp.Y} {
+			if c, ok := operand.(*ssa.Const); ok && c.IsNil() {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package rsacheck
+
+import (
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// nolinted reports whether the line of the given position has a //nolint comment that
+// applies to the analyzer: either //nolint, which applies to all linters, or a list of
+// linters that includes rsalint or all, such as //nolint:rsalint,gosec. As with other
+// linters, an explanation can follow the directive, such as //nolint:rsalint // test key.
+func nolinted(pass *analysis.Pass, pos token.Pos) bool {
+	file, ok := fileOf(pass, pos)
+	if !ok {
+		return false
+	}
+
+	line := pass.Fset.Position(pos).Line
+
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			if pass.Fset.Position(comment.Slash).Line == line && nolintDirective(comment.Text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nolintDirective reports whether the comment is a //nolint directive that applies to
+// the analyzer.
+func nolintDirective(text string) bool {
+	directive, ok := strings.CutPrefix(text, "//nolint")
+	if !ok {
+		return false
+	}
+
+	// Drop the explanation, if any.
+	directive, _, _ = strings.Cut(directive, "//")
+	directive = strings.TrimSpace(directive)
+
+	if directive == "" {
+		return true
+	}
+
+	linters, ok := strings.CutPrefix(directive, ":")
+	if !ok {
+		return false
+	}
+
+	for _, linter := range strings.Split(linters, ",") {
+		if linter = strings.TrimSpace(linter); linter == "rsalint" || linter == "all" {
+			return true
+		}
+	}
+	return false
+}
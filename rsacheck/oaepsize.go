@@ -0,0 +1,87 @@
+package rsacheck
+
+import (
+	"go/constant"
+	"go/types"
+	"maps"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkOAEPMessageSize checks if the message given to [crypto/rsa.EncryptOAEP] is too long
+// to be encrypted with the key and hash, which always fails at runtime. OAEP can encrypt
+// at most k - 2*hLen - 2 bytes, where k is the size of the key, and hLen the size of the
+// hash, in bytes.
+//
+// The key must be generated with a constant number of bits in the same function, the hash
+// created by a standard library function, and the message have a constant length.
+func checkOAEPMessageSize(pass *analysis.Pass, instr *ssa.Call) {
+	call, ok := callTo(instr.Call.Args[0], slices.Collect(maps.Keys(hashFunctions))...)
+	if !ok {
+		return
+	}
+	hash := hashFunctions[calleeName(call)]
+
+	bits, ok := publicKeyBits(instr.Call.Args[2])
+	if !ok {
+		return
+	}
+
+	n, ok := constLen(instr.Call.Args[3])
+	if !ok {
+		return
+	}
+
+	limit := max((bits+7)/8-2*int64(hash.Size())-2, 0)
+	if n > limit {
+		reportf(pass, instr.Pos(), oaepMessageSizeMessage, n, bits, hash, limit)
+	}
+}
+
+// publicKeyBits returns the constant number of bits of the given public key, if it's
+// the PublicKey field of a private key generated in the same function (&key.PublicKey).
+func publicKeyBits(pub ssa.Value) (int64, bool) {
+	addr, ok := pub.(*ssa.FieldAddr)
+	if !ok || fieldName(addr) != "PublicKey" {
+		return 0, false
+	}
+
+	call, ok := callTo(addr.X, generateKey, generateMultiPrimeKey)
+	if !ok {
+		return 0, false
+	}
+
+	return constBits(call.Call.Args[len(call.Call.Args)-1])
+}
+
+// constLen returns the constant length of the given byte slice, if it's converted from
+// a constant string, or slices an array.
+func constLen(value ssa.Value) (int64, bool) {
+	switch value := value.(type) {
+	case *ssa.Convert:
+		c, ok := value.X.(*ssa.Const)
+		if !ok || c.Value == nil || c.Value.Kind() != constant.String {
+			return 0, false
+		}
+		return int64(len(constant.StringVal(c.Value))), true
+	case *ssa.Slice:
+		if value.Low != nil || value.High != nil {
+			return 0, false
+		}
+
+		ptr, ok := value.X.Type().Underlying().(*types.Pointer)
+		if !ok {
+			return 0, false
+		}
+
+		array, ok := ptr.Elem().Underlying().(*types.Array)
+		if !ok {
+			return 0, false
+		}
+		return array.Len(), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,131 @@
+package rsacheck
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// Functions that serialize private keys, and that write bytes out.
+const (
+	marshalPKCS1PrivateKey = "crypto/x509.MarshalPKCS1PrivateKey"
+	marshalPKCS8PrivateKey = "crypto/x509.MarshalPKCS8PrivateKey"
+	osWriteFile            = "os.WriteFile"
+	pemEncode              = "encoding/pem.Encode"
+	pemEncodeToMemory      = "encoding/pem.EncodeToMemory"
+	encryptPEMBlock        = "crypto/x509.EncryptPEMBlock"
+)
+
+// checkPlaintextKey checks if an RSA private key serialized by the given call, to
+// x509.MarshalPKCS1PrivateKey or x509.MarshalPKCS8PrivateKey, is written out without
+// being encrypted first, such as by os.WriteFile, or the Write method of an io.Writer,
+// possibly after PEM encoding it. Key bytes passed to any other function, such as an
+// AEAD's Seal method, are assumed to be encrypted, and aren't followed.
+func checkPlaintextKey(pass *analysis.Pass, instr *ssa.Call) {
+	if !configOf(pass).PlaintextKey || len(instr.Call.Args) != 1 {
+		return
+	}
+
+	if !isRSAType(unwrapInterface(instr.Call.Args[0]).Type(), "PrivateKey") {
+		return
+	}
+
+	var der ssa.Value = instr
+	if _, ok := instr.Type().(*types.Tuple); ok {
+		der = nil
+		for _, ref := range *instr.Referrers() {
+			if extract, ok := ref.(*ssa.Extract); ok && extract.Index == 0 {
+				der = extract
+			}
+		}
+		if der == nil {
+			return
+		}
+	}
+
+	write, ok := writtenBy(der, map[ssa.Value]bool{})
+	if !ok {
+		return
+	}
+
+	report(pass, plaintextKeyMessage, analysis.Diagnostic{
+		Pos:     instr.Pos(),
+		Message: fmt.Sprintf(plaintextKeyMessage, strings.TrimPrefix(calleeName(instr), "crypto/")),
+		Related: []analysis.RelatedInformation{
+			{Pos: write.Pos(), Message: "the unencrypted key is written here"},
+		},
+	})
+}
+
+// checkEncryptPEMBlock reports calls to x509.EncryptPEMBlock, which is sometimes used to
+// protect RSA private keys, but is deprecated, since legacy PEM encryption uses an MD5
+// based KDF, and an unauthenticated cipher vulnerable to padding oracle attacks.
+func checkEncryptPEMBlock(pass *analysis.Pass, instr *ssa.Call) {
+	reportf(pass, instr.Pos(), encryptPEMBlockMessage)
+}
+
+// writtenBy returns the call that writes out the given bytes, following conversions,
+// slicing, and PEM blocks whose Bytes field they're stored to.
+func writtenBy(value ssa.Value, seen map[ssa.Value]bool) (*ssa.Call, bool) {
+	if seen[value] || value.Referrers() == nil {
+		return nil, false
+	}
+	seen[value] = true
+
+	for _, ref := range *value.Referrers() {
+		var next ssa.Value
+
+		switch ref := ref.(type) {
+		case *ssa.Call:
+			switch {
+			case isWrite(ref):
+				return ref, true
+			case calleeName(ref) == pemEncodeToMemory:
+				next = ref
+			}
+		case *ssa.Convert:
+			next = ref
+		case *ssa.ChangeType:
+			next = ref
+		case *ssa.MakeInterface:
+			next = ref
+		case *ssa.Slice:
+			next = ref
+		case *ssa.UnOp:
+			next = ref
+		case *ssa.Store:
+			// Bytes stored to the Bytes field of a PEM block flow to the block.
+			if addr, ok := ref.Addr.(*ssa.FieldAddr); ok && ref.Val == value && fieldName(addr) == "Bytes" && isType(addr.X.Type(), "encoding/pem", "Block") {
+				next = addr.X
+			}
+		}
+
+		if next == nil {
+			continue
+		}
+
+		if call, ok := writtenBy(next, seen); ok {
+			return call, true
+		}
+	}
+
+	return nil, false
+}
+
+// isWrite reports whether the given call writes its arguments out, such as to a file.
+func isWrite(call *ssa.Call) bool {
+	switch calleeName(call) {
+	case osWriteFile, pemEncode:
+		return true
+	}
+
+	if call.Call.IsInvoke() {
+		return call.Call.Method.Name() == "Write"
+	}
+
+	callee := call.Call.StaticCallee()
+	return callee != nil && callee.Signature.Recv() != nil && callee.Name() == "Write"
+}
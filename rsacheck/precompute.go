@@ -0,0 +1,48 @@
+package rsacheck
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+const privateKeyPrecompute = "(*crypto/rsa.PrivateKey).Precompute"
+
+// checkPrecompute checks if the private key returned by the given key generation call is
+// used to sign or decrypt in a loop, without calling its Precompute method in the same
+// function. Precomputed CRT values make repeated private key operations much faster, so
+// this is only an advisory for code that performs many of them.
+func checkPrecompute(pass *analysis.Pass, instr *ssa.Call) {
+	if !configOf(pass).Perf {
+		return
+	}
+
+	for _, ref := range *instr.Referrers() {
+		key, ok := ref.(*ssa.Extract)
+		if !ok || key.Index != 0 {
+			continue
+		}
+
+		if len(keyCalls(key, privateKeyPrecompute)) > 0 {
+			return
+		}
+
+		for _, call := range keyCalls(key, slices.Concat(signFuncs, decryptFuncs)...) {
+			if _, ok := innermostLoop(call.Block()); !ok {
+				continue
+			}
+
+			report(pass, precomputeMessage, analysis.Diagnostic{
+				Pos:     call.Pos(),
+				Message: fmt.Sprintf(precomputeMessage, strings.TrimPrefix(calleeName(call), "crypto/")),
+				Related: []analysis.RelatedInformation{
+					{Pos: instr.Pos(), Message: "the key is generated here"},
+				},
+			})
+			return
+		}
+	}
+}
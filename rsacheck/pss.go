@@ -0,0 +1,49 @@
+package rsacheck
+
+import (
+	"crypto"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkPSSSaltLength checks if the options given to [crypto/rsa.SignPSS] set a salt length
+// smaller than the size of the hash, such as &rsa.PSSOptions{SaltLength: 8} with SHA-256,
+// which weakens the security proof of PSS. The sentinels rsa.PSSSaltLengthAuto (0) and
+// rsa.PSSSaltLengthEqualsHash (-1) aren't reported.
+//
+// The options must be a struct literal, or a variable assigned one, in the same function,
+// with a constant salt length, and the hash a constant crypto.Hash.
+func checkPSSSaltLength(pass *analysis.Pass, instr *ssa.Call, hash, opts ssa.Value) {
+	h, ok := signatureHash(hash)
+	if !ok {
+		return
+	}
+
+	alloc, ok := storedValue(opts).(*ssa.Alloc)
+	if !ok || alloc.Comment != "complit" || !isRSAType(alloc.Type(), "PSSOptions") {
+		return
+	}
+
+	saltLength, ok := constBits(storedField(alloc, "SaltLength"))
+	if !ok || saltLength <= 0 || saltLength >= int64(h.Size()) {
+		return
+	}
+
+	reportf(pass, instr.Pos(), pssSaltLengthMessage, saltLength, h.Size(), h)
+}
+
+// signatureHash returns the constant crypto.Hash given as the hash of a signature, if it's
+// a known hash function, whose size is available.
+func signatureHash(value ssa.Value) (crypto.Hash, bool) {
+	c, ok := value.(*ssa.Const)
+	if !ok || c.Value == nil || !isType(c.Type(), "crypto", "Hash") {
+		return 0, false
+	}
+
+	h := crypto.Hash(c.Int64())
+	if h == 0 || h > maxHash {
+		return 0, false
+	}
+	return h, true
+}
@@ -0,0 +1,78 @@
+package rsacheck
+
+import (
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// publicFuncs returns the functions that make up the public API surface of a package:
+// exported functions and methods, the main function of a command, the closures within them, and unexported functions
+// they call with one of their own parameters, since the value of that parameter is
+// then controlled by the caller of the exported function.
+func publicFuncs(funcs []*ssa.Function) map[*ssa.Function]bool {
+	public := map[*ssa.Function]bool{}
+
+	var visit func(fn *ssa.Function)
+	visit = func(fn *ssa.Function) {
+		if public[fn] {
+			return
+		}
+		public[fn] = true
+
+		for _, anon := range fn.AnonFuncs {
+			visit(anon)
+		}
+
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				call, ok := instr.(*ssa.Call)
+				if !ok {
+					continue
+				}
+
+				callee := call.Call.StaticCallee()
+				if callee == nil || callee.Pkg != fn.Pkg {
+					continue
+				}
+
+				for _, arg := range call.Call.Args {
+					if _, ok := arg.(*ssa.Parameter); ok {
+						visit(callee)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	for _, fn := range funcs {
+		if obj := fn.Object(); obj != nil && (obj.Exported() || entryPoint(fn)) {
+			visit(fn)
+		}
+	}
+
+	return public
+}
+
+// entryPoint reports whether the given function is called by the runtime, which makes
+// it part of a command's API surface: the main function of a main package.
+func entryPoint(fn *ssa.Function) bool {
+	return fn.Pkg != nil && fn.Pkg.Pkg.Name() == "main" && fn.Name() == "main" && fn.Signature.Recv() == nil
+}
+
+// benchmark reports whether the given function is a benchmark in a _test.go file,
+// or a closure within one, such as a sub-benchmark passed to [testing.B.Run].
+func benchmark(pass *analysis.Pass, fn *ssa.Function) bool {
+	for fn.Parent() != nil {
+		fn = fn.Parent()
+	}
+
+	if !strings.HasPrefix(fn.Name(), "Benchmark") || fn.Signature.Recv() != nil {
+		return false
+	}
+
+	file := pass.Fset.File(fn.Pos())
+	return file != nil && strings.HasSuffix(file.Name(), "_test.go")
+}
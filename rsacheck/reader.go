@@ -0,0 +1,319 @@
+package rsacheck
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/ssa"
+)
+
+// ReaderKind classifies the source of randomness given to an RSA function.
+type ReaderKind int
+
+const (
+	// Unknown readers can't be classified, such as function parameters, or
+	// readers returned by functions outside of the standard library.
+	Unknown ReaderKind = iota
+	// SecureCryptoRand is the crypto/rand.Reader, a cryptographically secure
+	// random number generator.
+	SecureCryptoRand
+	// MathRand readers are from the math/rand (or math/rand/v2) package, which
+	// is not cryptographically secure.
+	MathRand
+	// Deterministic readers always return the same bytes, such as a
+	// bytes.Reader or strings.Reader, or a seeded math/rand/v2.ChaCha8.
+	Deterministic
+	// CustomTrusted readers are configured as trusted using the
+	// -trusted-readers flag, such as a reader backed by an HSM.
+	CustomTrusted
+)
+
+// String returns the name of the reader kind.
+func (k ReaderKind) String() string {
+	switch k {
+	case SecureCryptoRand:
+		return "SecureCryptoRand"
+	case MathRand:
+		return "MathRand"
+	case Deterministic:
+		return "Deterministic"
+	case CustomTrusted:
+		return "CustomTrusted"
+	default:
+		return "Unknown"
+	}
+}
+
+// Secure reports whether the reader kind is known to be cryptographically secure.
+func (k ReaderKind) Secure() bool {
+	return k == SecureCryptoRand || k == CustomTrusted
+}
+
+// deterministicReaders are functions that return readers with fixed contents.
+var deterministicReaders = map[string]bool{
+	"bytes.NewReader":         true,
+	"bytes.NewBuffer":         true,
+	"bytes.NewBufferString":   true,
+	"strings.NewReader":       true,
+	"math/rand/v2.NewChaCha8": true,
+}
+
+// deterministicReaderTypes are reader types with fixed contents.
+var deterministicReaderTypes = map[string]bool{
+	"*bytes.Reader":         true,
+	"*bytes.Buffer":         true,
+	"*strings.Reader":       true,
+	"*math/rand/v2.ChaCha8": true,
+}
+
+// ClassifyReader classifies the given SSA value, used as the source of randomness
+// for an RSA function, by following it back to where the reader was created. It uses
+// [DefaultConfig], which trusts no custom readers; use [Config.ClassifyReader] for the
+// readers trusted by a configuration to be CustomTrusted.
+func ClassifyReader(v ssa.Value) ReaderKind {
+	return classifyReader(v, DefaultConfig.TrustedReaders)
+}
+
+// ClassifyReader classifies the given reader, as [ClassifyReader], except that readers
+// in the configuration's TrustedReaders are CustomTrusted.
+func (cfg *Config) ClassifyReader(v ssa.Value) ReaderKind {
+	return classifyReader(v, cfg.TrustedReaders)
+}
+
+// classifyReader classifies the given reader, as [ClassifyReader], with the given
+// trusted readers.
+func classifyReader(value ssa.Value, trustedReaders readerList) ReaderKind {
+	switch value := value.(type) {
+	case *ssa.MakeInterface:
+		if kind := classifyReaderType(value.X.Type()); kind != Unknown {
+			return kind
+		}
+		return classifyReader(value.X, trustedReaders)
+	case *ssa.ChangeInterface:
+		return classifyReader(value.X, trustedReaders)
+	case *ssa.UnOp:
+		global, ok := value.X.(*ssa.Global)
+		if value.Op != token.MUL || !ok {
+			return Unknown
+		}
+
+		switch name := global.String(); {
+		case name == randomReader:
+			return SecureCryptoRand
+		case trustedReaders.contains(name):
+			return CustomTrusted
+		}
+	case *ssa.Call:
+		name := value.Call.Value.String()
+
+		switch {
+		case name == randomReader:
+			return SecureCryptoRand
+		case trustedReaders.contains(name):
+			return CustomTrusted
+		case deterministicReaders[name]:
+			return Deterministic
+		case isMathRand(calleePackage(value)):
+			return MathRand
+		}
+
+		return classifyReaderType(value.Type())
+	}
+
+	return Unknown
+}
+
+// classifyReaderType classifies a reader by its concrete type.
+func classifyReaderType(typ types.Type) ReaderKind {
+	if deterministicReaderTypes[typ.String()] {
+		return Deterministic
+	}
+
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+
+	if named, ok := typ.(*types.Named); ok && named.Obj().Pkg() != nil && isMathRand(named.Obj().Pkg().Path()) {
+		return MathRand
+	}
+
+	return Unknown
+}
+
+// isMathRand reports whether the given package path is math/rand or math/rand/v2.
+func isMathRand(path string) bool {
+	return path == "math/rand" || path == "math/rand/v2"
+}
+
+// calleePackage returns the package path of the statically called function, or
+// an empty string if the call is dynamic.
+func calleePackage(call *ssa.Call) string {
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Object() == nil || callee.Object().Pkg() == nil {
+		return ""
+	}
+	return callee.Object().Pkg().Path()
+}
+
+// readerList is a comma-separated list of fully qualified function or variable
+// names, implementing flag.Value.
+type readerList []string
+
+func (l *readerList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *readerList) Set(s string) error {
+	*l = nil
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			*l = append(*l, name)
+		}
+	}
+	return nil
+}
+
+func (l *readerList) contains(name string) bool {
+	for _, n := range *l {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// mathRandSeeded returns a call to [math/rand.Seed] in the package being analyzed, if
+// enabled. Seeding the global source suggests the package relies on math/rand, which
+// raises the confidence that a weak random reader is actually predictable.
+func mathRandSeeded(pass *analysis.Pass) (*ssa.Call, bool) {
+	if !configOf(pass).SeededRand {
+		return nil, false
+	}
+
+	ir := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+
+	for _, fn := range ir.SrcFuncs {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				if call, ok := instr.(*ssa.Call); ok && call.Call.Value.String() == mathRandSeed {
+					return call, true
+				}
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// checkPredictableSeed checks if a math/rand reader is created from a source with a
+// constant seed, such as rand.New(rand.NewSource(0)), which generates the same key on
+// every run, or seeded with the current time, such as time.Now().UnixNano(), which can
+// be guessed from when the key was generated. It's reported in addition to the use of
+// math/rand, since a predictable seed is the worst case, even for code where math/rand
+// is otherwise accepted.
+func checkPredictableSeed(pass *analysis.Pass, instr *ssa.Call, value ssa.Value) {
+	rng, ok := callTo(storedValue(unwrapInterface(value)), mathRandNew)
+	if !ok || len(rng.Call.Args) != 1 {
+		return
+	}
+
+	source, ok := callTo(storedValue(unwrapInterface(rng.Call.Args[0])), mathRandNewSource)
+	if !ok || len(source.Call.Args) != 1 {
+		return
+	}
+
+	if seed, ok := constBits(source.Call.Args[0]); ok {
+		report(pass, predictableSeedMessage, analysis.Diagnostic{
+			Pos:     instr.Pos(),
+			Message: predictableSeedMessage,
+			Related: []analysis.RelatedInformation{
+				{Pos: source.Pos(), Message: fmt.Sprintf("math/rand source is seeded with the constant %d here", seed)},
+			},
+		})
+		return
+	}
+
+	if now, ok := timeSeed(source.Call.Args[0]); ok {
+		report(pass, timeSeedMessage, analysis.Diagnostic{
+			Pos:     instr.Pos(),
+			Message: timeSeedMessage,
+			Related: []analysis.RelatedInformation{
+				{Pos: source.Pos(), Message: "math/rand source is seeded here"},
+				{Pos: now.Pos(), Message: "with the current time from here"},
+			},
+		})
+	}
+}
+
+// timeUnixFuncs are the methods of [time.Time] that return it as a Unix time, which are
+// used to seed math/rand sources.
+var timeUnixFuncs = []string{
+	"(time.Time).Unix",
+	"(time.Time).UnixMilli",
+	"(time.Time).UnixMicro",
+	"(time.Time).UnixNano",
+}
+
+// timeSeed returns the call to [time.Now] that the given seed is derived from, such as
+// time.Now().UnixNano(), or a variable assigned it, converted to int64 if needed.
+func timeSeed(seed ssa.Value) (*ssa.Call, bool) {
+	unix, ok := callTo(storedValue(seed), timeUnixFuncs...)
+	if !ok || len(unix.Call.Args) != 1 {
+		return nil, false
+	}
+	return callTo(storedValue(unix.Call.Args[0]), timeNow)
+}
+
+// checkMutableReader checks if the random reader is a package variable of the package being
+// analyzed that is reassigned outside of its declaration, including in tests. Tests often
+// replace such a variable with a deterministic reader, which could leak into production.
+//
+// It reports whether the finding was reported, which is only done when enabled.
+func checkMutableReader(pass *analysis.Pass, instr *ssa.Call, value ssa.Value) bool {
+	if !configOf(pass).MutableReader {
+		return false
+	}
+
+	load, ok := unwrapInterface(value).(*ssa.UnOp)
+	if !ok || load.Op != token.MUL {
+		return false
+	}
+
+	global, ok := load.X.(*ssa.Global)
+	if !ok || global.Pkg.Pkg != pass.Pkg {
+		return false
+	}
+
+	ir := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+
+	for _, fn := range ir.SrcFuncs {
+		// The package initializer stores the variable's declared value.
+		if fn.Synthetic != "" {
+			continue
+		}
+
+		for _, b := range fn.Blocks {
+			for _, other := range b.Instrs {
+				store, ok := other.(*ssa.Store)
+				if !ok || store.Addr != global {
+					continue
+				}
+
+				report(pass, mutableReaderMessage, analysis.Diagnostic{
+					Pos:     instr.Pos(),
+					Message: fmt.Sprintf(mutableReaderMessage, global.Name()),
+					Related: []analysis.RelatedInformation{
+						{Pos: store.Pos(), Message: fmt.Sprintf("%v is reassigned here", global.Name())},
+					},
+				})
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package rsacheck
+
+import (
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkRecoveredKeyGeneration checks if the function generating a key defers a closure
+// that calls recover, which silently hides key generation failures, such as a panic
+// from a faulty random reader, leaving the program running without a valid key.
+func checkRecoveredKeyGeneration(pass *analysis.Pass, instr *ssa.Call) {
+	if !configOf(pass).RecoveredKeyGen {
+		return
+	}
+
+	for _, b := range instr.Parent().Blocks {
+		for _, other := range b.Instrs {
+			deferred, ok := other.(*ssa.Defer)
+			if !ok {
+				continue
+			}
+
+			if call, ok := recoverCall(deferred.Call.Value); ok {
+				report(pass, recoveredKeyGenMessage, analysis.Diagnostic{
+					Pos:     instr.Pos(),
+					Message: recoveredKeyGenMessage,
+					Related: []analysis.RelatedInformation{
+						{Pos: call.Pos(), Message: "panics are recovered here"},
+					},
+				})
+				return
+			}
+		}
+	}
+}
+
+// recoverCall returns the call to recover in the given deferred function, if any.
+func recoverCall(value ssa.Value) (*ssa.Call, bool) {
+	var fn *ssa.Function
+	switch value := value.(type) {
+	case *ssa.Function:
+		fn = value
+	case *ssa.MakeClosure:
+		fn, _ = value.Fn.(*ssa.Function)
+	}
+
+	if fn == nil {
+		return nil, false
+	}
+
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok {
+				continue
+			}
+
+			if builtin, ok := call.Call.Value.(*ssa.Builtin); ok && builtin.Name() == "recover" {
+				return call, true
+			}
+		}
+	}
+
+	return nil, false
+}
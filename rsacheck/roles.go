@@ -0,0 +1,116 @@
+package rsacheck
+
+import (
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// keyArg is the argument of an RSA function that takes a key.
+type keyArg struct {
+	// index of the argument.
+	index int
+
+	// private is set if the function requires a private key.
+	private bool
+}
+
+// keyArgs are the key arguments of RSA functions, by function name.
+var keyArgs = map[string]keyArg{
+	signPKCS1v15:    {index: 1, private: true},
+	signPSS:         {index: 1, private: true},
+	decryptPKCS1v15: {index: 1, private: true},
+	decryptOAEP:     {index: 2, private: true},
+	verifyPKCS1v15:  {index: 0},
+	verifyPSS:       {index: 0},
+	encryptPKCS1v15: {index: 1},
+	encryptOAEP:     {index: 2},
+}
+
+// checkKeyRole checks if the key given to an RSA function plays the wrong role, because
+// a private key is converted to a public key using unsafe.Pointer, instead of using its
+// PublicKey field, or the other way around, or a private key is constructed from only a
+// public key. The type system normally prevents swapping keys, so only mismatches that
+// circumvent it, and are statically detectable, are reported.
+func checkKeyRole(pass *analysis.Pass, instr *ssa.Call) {
+	name := checkedCallee(instr)
+
+	arg, ok := keyArgs[name]
+	if !ok {
+		return
+	}
+
+	key := instr.Call.Args[arg.index]
+	fn := strings.TrimPrefix(name, "crypto/")
+
+	if arg.private {
+		if unsafeConversion(key, "PublicKey") || publicOnlyKey(key) {
+			reportf(pass, instr.Pos(), publicKeyAsPrivateMessage, fn)
+		}
+		return
+	}
+
+	if unsafeConversion(key, "PrivateKey") {
+		reportf(pass, instr.Pos(), privateKeyAsPublicMessage, fn)
+	}
+}
+
+// unsafeConversion reports whether the given value is converted through an
+// unsafe.Pointer from the named type of the "crypto/rsa" package.
+func unsafeConversion(value ssa.Value, name string) bool {
+	conv, ok := value.(*ssa.Convert)
+	if !ok {
+		return false
+	}
+
+	ptr, ok := conv.X.(*ssa.Convert)
+	if !ok {
+		return false
+	}
+
+	if basic, ok := ptr.Type().Underlying().(*types.Basic); !ok || basic.Kind() != types.UnsafePointer {
+		return false
+	}
+
+	return isRSAType(ptr.X.Type(), name)
+}
+
+// publicOnlyKey reports whether the given value is a private key constructed in the
+// same function, such as &rsa.PrivateKey{PublicKey: *pub}, without setting its private
+// exponent D.
+func publicOnlyKey(value ssa.Value) bool {
+	alloc, ok := value.(*ssa.Alloc)
+	if !ok || !isRSAType(alloc.Type(), "PrivateKey") {
+		return false
+	}
+
+	var public bool
+
+	for _, ref := range *alloc.Referrers() {
+		addr, ok := ref.(*ssa.FieldAddr)
+		if !ok || !stored(addr) {
+			continue
+		}
+
+		switch fieldName(addr) {
+		case "D":
+			return false
+		case "PublicKey":
+			public = true
+		}
+	}
+
+	return public
+}
+
+// stored reports whether a value is stored to the given address.
+func stored(addr *ssa.FieldAddr) bool {
+	for _, ref := range *addr.Referrers() {
+		if store, ok := ref.(*ssa.Store); ok && store.Addr == addr {
+			return true
+		}
+	}
+	return false
+}
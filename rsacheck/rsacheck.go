@@ -2,6 +2,11 @@
 package rsacheck
 
 import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/ssa"
@@ -13,16 +18,101 @@ const (
 	generateKey           = "crypto/rsa.GenerateKey"
 	generateMultiPrimeKey = "crypto/rsa.GenerateMultiPrimeKey"
 	encryptPKCS1v15       = "crypto/rsa.EncryptPKCS1v15"
+	encryptOAEP           = "crypto/rsa.EncryptOAEP"
+	signPKCS1v15          = "crypto/rsa.SignPKCS1v15"
+	signPSS               = "crypto/rsa.SignPSS"
+	verifyPKCS1v15        = "crypto/rsa.VerifyPKCS1v15"
+	verifyPSS             = "crypto/rsa.VerifyPSS"
+	decryptPKCS1v15       = "crypto/rsa.DecryptPKCS1v15"
+	decryptOAEP           = "crypto/rsa.DecryptOAEP"
+	osReadFile            = "os.ReadFile"
+	syncPoolGet           = "(*sync.Pool).Get"
+	publicKeySize         = "(*crypto/rsa.PublicKey).Size"
+	bigIntBitLen          = "(*math/big.Int).BitLen"
+	reflectDeepEqual      = "reflect.DeepEqual"
+	base64EncodeToString  = "(*encoding/base64.Encoding).EncodeToString"
+	ioReadAll             = "io.ReadAll"
+	mathRandSeed          = "math/rand.Seed"
+	mathRandNew           = "math/rand.New"
+	mathRandNewSource     = "math/rand.NewSource"
+	timeNow               = "time.Now"
+	cryptoHashSize        = "(crypto.Hash).Size"
 )
 
 // Messages that are reported by this analyzer.
 const (
-	randSourceLintMessage     = "use the crypto/rand.Reader for a cryptographically secure random number generator"
-	numberOfbitsLintMessage   = "use 2048 bits or greater"
-	numberOfPrimesLintMessage = "for %v bits %v is the max number of primes to use"
-	multipleOf8BitsMessage    = "use a multiple of 8 bits for RSA keys"
-	generateKeyMessage        = "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
-	oaepMessage               = "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15"
+	randSourceLintMessage         = "use the crypto/rand.Reader for a cryptographically secure random number generator"
+	mathRandMessage               = "math/rand is not cryptographically secure; use crypto/rand.Reader"
+	mutableReaderMessage          = "random reader %v is a package variable that is reassigned, and could be replaced with a weak random source; pass the reader explicitly instead"
+	recoveredKeyGenMessage        = "do not recover from panics around RSA key generation; failures to generate a key should not be silently ignored"
+	testKeyLeakMessage            = "RSA key generated in %v is stored in package variable %v, which is declared in non-test code; a test key could leak into production"
+	nilReaderMessage              = "random reader parameter %v is passed to %v without handling nil; fall back to the crypto/rand.Reader if it's nil, or return an error"
+	testHelperReaderMessage       = "random reader %v is from test helper package %v, and may be deterministic; use the crypto/rand.Reader in production code"
+	wasmReaderMessage             = "use the crypto/rand.Reader instead of a fallback random reader for WebAssembly; it is available under GOOS=js and GOOS=wasip1"
+	seededRandMessage             = "use the crypto/rand.Reader for a cryptographically secure random number generator; the package seeds math/rand, so the random source is likely predictable"
+	predictableSeedMessage        = "predictable seed makes key generation deterministic"
+	timeSeedMessage               = "time-based seeds are predictable; use crypto/rand.Reader"
+	numberOfbitsLintMessage       = "use %v bits or greater"
+	hardcodedKeyCompareMessage    = "RSA key %v is compared to a hardcoded value, which may indicate an embedded test key or backdoor"
+	unmarshaledBitsMessage        = "number of bits is unmarshaled, and may be controlled by users; enforce a minimum of %v bits after unmarshaling"
+	possibleBitsMessage           = "number of bits may be %v depending on the path taken; use %v bits or greater"
+	weakKeyUseMessage             = "RSA key with %v bits is used for %v; use %v bits or greater"
+	hashSizeBitsMessage           = "%v is the size of a hash in bytes, not the number of bits of an RSA key; use %v bits or greater"
+	numberOfPrimesLintMessage     = "for %v bits %v is the max number of primes to use"
+	multipleOf8BitsMessage        = "use a multiple of 8 bits for RSA keys"
+	generateKeyMessage            = "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+	pssSaltLengthMessage          = "PSS salt length is too small; %v bytes is less than the %v-byte %v digest, use rsa.PSSSaltLengthEqualsHash"
+	keyReuseMessage               = "avoid reusing an RSA key for both signing and encryption"
+	suggestEdDSAMessage           = "RSA key is only used for signing; for new code, prefer ed25519.GenerateKey or ecdsa.GenerateKey, whose keys and signatures are smaller and faster"
+	pssMessage                    = "prefer rsa.SignPSS over rsa.SignPKCS1v15 for new code"
+	oaepMessage                   = "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15"
+	insecureSkipVerifyMessage     = "InsecureSkipVerify is set in a function that uses an RSA key; the strength of the key is moot if certificates aren't verified"
+	paddingOracleMessage          = "PKCS#1 v1.5 decryption is vulnerable to padding oracles; prefer rsa.DecryptOAEP, or rsa.DecryptPKCS1v15SessionKey to decrypt session keys in constant time"
+	storedCiphertextMessage       = "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15; the ciphertext is stored, which will make migrating away from it harder"
+	bulkEncryptionMessage         = "do not encrypt bulk data with RSA; use hybrid encryption and only encrypt a symmetric key with RSA"
+	encryptInLoopMessage          = "rsa.EncryptOAEP is called in a loop over an unbounded number of messages; limit the input or use hybrid encryption"
+	pooledReaderMessage           = "verify the random reader obtained from a sync.Pool is a cryptographically secure random number generator"
+	smallExponentMessage          = "use a public exponent of 65537; small exponents such as %v are vulnerable to several attacks"
+	brokenKeyMessage              = "RSA key with %v bits and public exponent %v is broken; use %v bits or greater and an exponent of 65537"
+	deepEqualMessage              = "do not compare RSA private keys with reflect.DeepEqual; use the constant-time PrivateKey.Equal method"
+	fipsMessage                   = "%v is not approved in FIPS 140 mode, and will fail at runtime"
+	unvalidatedKeySizeMessage     = "RSA key size is not constant; validate the size of the generated key using key.Size() or key.N.BitLen()"
+	keyLiteralBitsMessage         = "RSA key literal has a %v-bit modulus; use %v bits or greater"
+	keyLiteralMessage             = "RSA key is constructed from a literal without validating its size; check that its modulus has at least %v bits when it's loaded"
+	generatedBitsMessage          = "number of bits is set by %v, which is declared in generated file %v; key sizes in generated code are harder to audit"
+	featureFlagBitsMessage        = "number of bits is %v on the path taken by default, since feature flag %v defaults to %v; use %v bits or greater"
+	discardedSignatureMessage     = "signature returned by %v is never used; the signing is pointless, or the signature is mistakenly dropped"
+	discardedKeyMessage           = "RSA key is generated but overwritten before it's used; remove the redundant key generation"
+	unusedKeyMessage              = "RSA key is generated but never used; remove the redundant key generation"
+	ignoredErrorMessage           = "error returned by %v is ignored; handle it, since the key is nil if generation fails"
+	precomputeMessage             = "RSA private key is used by %v in a loop without calling Precompute; call key.Precompute() once before the loop"
+	longValidityMessage           = "RSA key with %v bits is used for a certificate valid for %v years, which the key's strength may not outlast; use %v bits or greater for long-lived certificates"
+	oaepMessageSizeMessage        = "message of %v bytes is too long for OAEP with a %v-bit key and %v, which can encrypt at most %v bytes"
+	oaepHashMessage               = "use a SHA-256 or stronger hash with OAEP instead of %v"
+	variableHashMessage           = "hash may be %v depending on the path taken, which is not collision resistant; use SHA-256 or stronger"
+	unhashedSignatureMessage      = "rsa.SignPKCS1v15 is called with crypto.Hash(0) on a raw message; you must hash the message before signing, and pass the hash function used, such as crypto.SHA256"
+	zeroHashMessage               = "do not sign/verify unhashed data; pass a real hash such as crypto.SHA256"
+	nonCryptoDigestMessage        = "signed digest is computed using %v, which is not a cryptographic hash; use a cryptographic hash such as SHA-256"
+	hashMismatchMessage           = "signature is verified using %v, but was signed using %v with the same key"
+	oaepHashMismatchMessage       = "ciphertext is decrypted using %v, but was encrypted using %v with the same key"
+	privateKeyAsPublicMessage     = "%v is called with a private key converted to a public key; use the key's PublicKey field instead"
+	publicKeyAsPrivateMessage     = "%v is called with a private key that only holds a public key; a private key with its private exponent is required"
+	gobPrivateKeyMessage          = "%v contains an RSA private key, which is serialized unprotected by encoding/gob; marshal it explicitly with x509.MarshalPKCS8PrivateKey and encrypt it"
+	encryptPEMBlockMessage        = "x509.EncryptPEMBlock is insecure and deprecated; use a modern KDF/AEAD"
+	plaintextKeyMessage           = "private key is being serialized unencrypted by %v and written out; encrypt it before it's stored"
+	internalErrorMessage          = "internal error analyzing %v at %v, which was skipped: %v"
+	unauthenticatedDecryptMessage = "ciphertext from an untrusted source is decrypted without verifying a MAC or signature first; RSA encryption is not authenticated"
+)
+
+// internalErrorCategory is the category of internal errors, which don't belong to a rule.
+const internalErrorCategory = "internal"
+
+// checkHook is called before the checks of each function, and checkPackageHook before
+// the checks of the whole package, if set. They're used by tests to simulate a panic in
+// a check.
+var (
+	checkHook        func(fn *ssa.Function)
+	checkPackageHook func(pkg *types.Package)
 )
 
 // maxPrimesTable is a table that maps the number of bits to the recommended number of primes to use.
@@ -38,51 +128,269 @@ var maxPrimesTable = map[int]int{
 }
 
 // Analyzer that reports insecure usage of the "crypto/rsa" package by checking for the following:
-//   - Weak random source (not using crypto/rand.Reader).
-//   - Weak number of bits (less than 2048, and not a multiple of 8).
+//   - Weak random source (not using crypto/rand.Reader), and math/rand sources with a constant or time-based seed.
+//   - Weak number of bits (less than 2048, or -min-bits, and not a multiple of 8), on any path taken.
+//   - Weak keys used for TLS, X.509 certificates, SSH, or JWTs.
+//   - Hash sizes in bytes (such as sha256.Size) used as the number of bits.
 //   - Weak number of primes for the given number of bits.
-//   - Deprecated functions (rsa.GenerateMultiPrimeKey).
+//   - Deprecated functions (rsa.GenerateMultiPrimeKey), and insecure PEM encryption (x509.EncryptPEMBlock).
 //   - Insecure encryption schemes (rsa.EncryptPKCS1v15).
-var Analyzer = &analysis.Analyzer{
-	Name: "rsalint",
-	Doc:  "report insecure usage of the \"crypto/rsa\" package",
-	Run:  run,
-	Requires: []*analysis.Analyzer{
-		buildssa.Analyzer,
-	},
-}
+//   - Small public exponents (less than 65537).
+//   - Parameters that aren't approved in FIPS 140 mode, when the package is built for it.
+//   - Mismatched hash algorithms when signing and verifying, or encrypting and decrypting
+//     with OAEP, with the same key.
+//   - Raw messages signed with crypto.Hash(0), instead of their digest.
+//   - Signed digests computed using non-cryptographic hashes (hash/fnv, hash/crc32).
+//   - PSS salt lengths smaller than the size of the hash.
+//   - Private keys used both for signing and for decryption in the same package.
+//   - Hash variables that may be a weak hash (SHA-1, MD5) depending on the path taken.
+//
+// Optional checks can be enabled using the analyzer's flags:
+//   - Bulk data encryption with RSA (-bulk-encryption).
+//   - Random readers obtained from a sync.Pool (-pooled-reader).
+//   - Encryption in unbounded loops (-encrypt-in-loop).
+//   - Stored rsa.EncryptPKCS1v15 ciphertexts (-stored-ciphertext).
+//   - Private keys compared with reflect.DeepEqual (-key-deep-equal).
+//   - Keys generated with a non-constant number of bits, without validating their size (-unvalidated-key-size).
+//   - Decryption of HTTP request bodies without verifying a MAC or signature (-unauthenticated-decrypt).
+//   - Weak random readers in packages that seed math/rand (-seeded-rand).
+//   - Random readers that are reassigned package variables (-mutable-reader).
+//   - Panics recovered around RSA key generation (-recovered-keygen).
+//   - Key sizes unmarshaled without enforcing a minimum (-unmarshaled-bits).
+//   - RSA keys compared to hardcoded values (-hardcoded-key-compare).
+//   - Weak fallback random readers in WebAssembly builds (-wasm-reader).
+//   - RSA private keys serialized using encoding/gob (-gob-private-key).
+//   - RSA private keys marshaled and written out without being encrypted (-plaintext-key).
+//   - Random readers from test helper packages in non-test files (-test-helper-reader).
+//   - Random reader parameters passed to RSA functions without handling nil (-nil-reader).
+//   - Keys generated in TestMain or Example functions stored in non-test package variables (-test-key-leak).
+//   - Keys with less than 3072 bits used for certificates valid for decades (-long-validity).
+//   - Weak key sizes selected by default by boolean feature flags (-feature-flags).
+//   - TLS configurations that skip verification in functions that use RSA keys (-insecure-skip-verify).
+//   - Key sizes set by constants declared in generated files (-generated-bits).
+//   - RSA keys constructed from struct literals with a weak or unvalidated modulus (-key-literal).
+//   - Errors of key generation that are ignored, while the key is used (-check-errors).
+//   - Generated keys used to sign or decrypt in a loop without calling Precompute (-perf).
+//   - RSA keys generated only to sign, where Ed25519 or ECDSA would be preferable (-suggest-eddsa).
+var Analyzer = NewAnalyzer(DefaultConfig)
 
 // checkSecureRandomReader checks if the random source is known secure (crypto/rand.Reader).
 // This is to avoid the use of a weak random source, which can be easily predicted, and thus broken.
 func checkSecureRandomReader(pass *analysis.Pass, instr *ssa.Call, value ssa.Value) {
-	switch value := value.(type) {
-	case *ssa.Call:
-		if value.Call.Value.String() != randomReader {
-			pass.Reportf(instr.Pos(), randSourceLintMessage)
+	cfg := configOf(pass)
+
+	// Readers assigned to a local variable are checked where they're created.
+	value = storedValue(value)
+
+	// A reader taken from a pool can't be resolved statically, so
+	// optionally advise to verify what the pool actually contains.
+	if assert, ok := value.(*ssa.TypeAssert); ok {
+		if _, ok := callTo(assert.X, syncPoolGet); ok && cfg.PooledReader {
+			reportf(pass, instr.Pos(), pooledReaderMessage)
 		}
-	case *ssa.MakeInterface:
-		checkSecureRandomReader(pass, instr, value.X)
+		return
+	}
+
+	if checkMutableReader(pass, instr, value) {
+		return
 	}
+
+	if checkTestHelperReader(pass, instr, value) {
+		return
+	}
+
+	kind := classifyReader(value, cfg.TrustedReaders)
+
+	switch kind {
+	case SecureCryptoRand, CustomTrusted:
+		return
+	case Unknown:
+		checkNilReader(pass, instr, value)
+
+		if !cfg.StrictRand {
+			return
+		}
+
+		// Only readers returned by a call are reported, since values such as
+		// function parameters can't be resolved within the function.
+		if _, ok := unwrapInterface(value).(*ssa.Call); !ok {
+			return
+		}
+	}
+
+	if cfg.WasmReader && wasmFallback(pass, instr, value) {
+		reportf(pass, instr.Pos(), wasmReaderMessage)
+		return
+	}
+
+	if kind == MathRand {
+		checkPredictableSeed(pass, instr, value)
+	}
+
+	if seed, ok := mathRandSeeded(pass); ok {
+		report(pass, seededRandMessage, analysis.Diagnostic{
+			Pos:     instr.Pos(),
+			Message: seededRandMessage,
+			Related: []analysis.RelatedInformation{
+				{Pos: seed.Pos(), Message: "math/rand is seeded here"},
+			},
+		})
+		return
+	}
+
+	if kind == MathRand {
+		reportf(pass, instr.Pos(), mathRandMessage)
+		return
+	}
+
+	reportf(pass, instr.Pos(), randSourceLintMessage)
 }
 
 // checkBits checks if the number of bits is within the recommended range for the given number of bits.
 // This is to avoid the use of RSA with a weak number of bits, which can be easily broken.
 //
-// The recommended number of bits is 2048 or greater, as per NIST SP 800-57 Part 1 Rev. 4.
+// The recommended number of bits is 2048 or greater, as per NIST SP 800-57 Part 1 Rev. 4,
+// and can be raised using the -min-bits flag, for policies that require 3072 or 4096 bits.
 // https://nvlpubs.nist.gov/nistpubs/SpecialPublications/NIST.SP.800-57pt1r4.pdf
 func checkBits(pass *analysis.Pass, instr *ssa.Call, bits ssa.Value) {
-	bitsValue, ok := bits.(*ssa.Const)
+	cfg := configOf(pass)
+
+	if checkHashSizeBits(pass, instr, bits) {
+		return
+	}
+
+	if phi, ok := bits.(*ssa.Phi); ok {
+		if cfg.FeatureFlags && checkFeatureFlagBits(pass, instr, phi) {
+			return
+		}
+
+		checkPossibleBits(pass, instr, bits)
+		return
+	}
+
+	checkUnmarshaledBits(pass, instr, bits)
+
+	n, ok := constBits(bits)
 	if !ok {
 		return
 	}
 
-	if bitsValue.Int64() < 2048 {
-		pass.Reportf(instr.Pos(), numberOfbitsLintMessage)
+	if cfg.GeneratedBits {
+		checkGeneratedBits(pass, instr, bits)
+	}
+
+	// Weak keys that are also given a small public exponent are reported together.
+	if n, weak := weakBits(bits, cfg.MinBits); weak {
+		if !checkWeakKey(pass, instr, bits) {
+			report(pass, numberOfbitsLintMessage, analysis.Diagnostic{
+				Pos:            instr.Pos(),
+				Message:        fmt.Sprintf(numberOfbitsLintMessage, cfg.MinBits),
+				SuggestedFixes: bitsFix(pass, instr),
+			})
+		}
+
+		checkWeakKeyUse(pass, instr, n)
+	} else if cfg.LongValidity {
+		checkLongValidity(pass, instr, n)
 	}
 
 	// Also ensure it's a proper multiple of 8
-	if bitsValue.Int64()%8 != 0 {
-		pass.Reportf(instr.Pos(), multipleOf8BitsMessage)
+	if n%8 != 0 {
+		reportf(pass, instr.Pos(), multipleOf8BitsMessage)
+	}
+}
+
+// weakBits returns the number of bits, and whether it's less than the given minimum
+// number of bits, which is 2048 unless configured using the -min-bits flag.
+func weakBits(bits ssa.Value, minBits int) (int64, bool) {
+	n, ok := constBits(bits)
+	if !ok {
+		return 0, false
+	}
+
+	return n, n < int64(minBits)
+}
+
+// constBits returns the constant number of bits, folding shifts, multiplications,
+// additions, and subtractions of constants, such as a named base constant shifted into
+// a variable (bits := base << 2), or multiplied (bits := base * 4), and resolving
+// variables that are assigned a constant once, such as a package-level var bits = 1024.
+func constBits(bits ssa.Value) (int64, bool) {
+	switch bits := bits.(type) {
+	case *ssa.UnOp:
+		if bits.Op != token.MUL {
+			return 0, false
+		}
+
+		if global, ok := bits.X.(*ssa.Global); ok {
+			value, ok := declaredValue(global)
+			if !ok {
+				return 0, false
+			}
+			return constBits(value)
+		}
+
+		if value := storedValue(bits); value != bits {
+			return constBits(value)
+		}
+	case *ssa.Const:
+		if bits.Value == nil || bits.Value.Kind() != constant.Int {
+			return 0, false
+		}
+		return bits.Int64(), true
+	case *ssa.Convert:
+		return constBits(bits.X)
+	case *ssa.BinOp:
+		x, ok := constBits(bits.X)
+		if !ok {
+			return 0, false
+		}
+
+		y, ok := constBits(bits.Y)
+		if !ok {
+			return 0, false
+		}
+
+		var folded constant.Value
+
+		switch bits.Op {
+		case token.SHL, token.SHR:
+			if y < 0 || y >= 63 {
+				return 0, false
+			}
+			folded = constant.Shift(constant.MakeInt64(x), bits.Op, uint(y))
+		case token.MUL, token.ADD, token.SUB:
+			folded = constant.BinaryOp(constant.MakeInt64(x), bits.Op, constant.MakeInt64(y))
+		default:
+			return 0, false
+		}
+
+		// Results that overflow are left alone, like any other dynamic value.
+		return constant.Int64Val(folded)
+	}
+
+	return 0, false
+}
+
+// checkPossibleBits checks if any of the constant values that the number of bits may have
+// is weak, such as bits selected by a switch statement, and reports the smallest one.
+func checkPossibleBits(pass *analysis.Pass, instr *ssa.Call, bits ssa.Value) {
+	minBits := configOf(pass).MinBits
+
+	var (
+		smallest int64
+		weak     bool
+	)
+
+	for _, value := range possibleValues(bits) {
+		// A zero value, such as an unhandled switch case, fails at runtime instead.
+		if n, ok := weakBits(value, minBits); ok && n > 0 && (!weak || n < smallest) {
+			smallest, weak = n, true
+		}
+	}
+
+	if weak {
+		reportf(pass, instr.Pos(), possibleBitsMessage, smallest, minBits)
 	}
 }
 
@@ -94,15 +402,81 @@ func checkNPrimesForBits(pass *analysis.Pass, instr *ssa.Call, nprimes, bits ssa
 		return
 	}
 
-	bitsValue, ok := bits.(*ssa.Const)
+	bitsValue, ok := constBits(bits)
 	if !ok {
 		return
 	}
 
-	recMaxNum, ok := maxPrimesTable[int(bitsValue.Int64())]
+	recMaxNum, ok := maxPrimesForBits(int(bitsValue))
 	if ok && nprimesValue.Int64() > int64(recMaxNum) {
-		pass.Reportf(instr.Pos(), numberOfPrimesLintMessage, bitsValue.Int64(), recMaxNum)
+		reportf(pass, instr.Pos(), numberOfPrimesLintMessage, bitsValue, recMaxNum)
+	}
+}
+
+// maxPrimesForBits returns the recommended maximum number of primes for the given number
+// of bits. Sizes between the entries of [maxPrimesTable], such as 3072 bits, use the
+// nearest smaller entry, since a key can't safely have more primes than a larger key,
+// and sizes below the smallest entry use it as well.
+func maxPrimesForBits(bits int) (int, bool) {
+	if bits <= 0 {
+		return 0, false
+	}
+
+	var (
+		entry    int
+		smallest int
+	)
+
+	for b := range maxPrimesTable {
+		if b <= bits && b > entry {
+			entry = b
+		}
+		if smallest == 0 || b < smallest {
+			smallest = b
+		}
+	}
+
+	if entry == 0 {
+		entry = smallest
 	}
+
+	return maxPrimesTable[entry], true
+}
+
+// arities are the number of arguments, including the receiver of methods, of the
+// functions whose calls are dispatched to checks, which index their arguments by
+// position.
+var arities = map[string]int{
+	generateKey:            2,
+	generateMultiPrimeKey:  3,
+	encryptPKCS1v15:        3,
+	encryptOAEP:            5,
+	signPKCS1v15:           4,
+	signPSS:                5,
+	verifyPKCS1v15:         4,
+	verifyPSS:              5,
+	decryptPKCS1v15:        3,
+	decryptOAEP:            5,
+	reflectDeepEqual:       2,
+	bigIntCmp:              2,
+	gobEncode:              2,
+	marshalPKCS1PrivateKey: 1,
+	marshalPKCS8PrivateKey: 1,
+	encryptPEMBlock:        5,
+}
+
+// checkedCallee returns the name of the function called by the given call, like
+// [calleeName], or an empty string if the call doesn't pass the number of arguments
+// the function takes, or invokes an interface method. Calls with an unexpected form,
+// such as of a different version of a function, are skipped by the checks instead of
+// causing a panic.
+func checkedCallee(instr *ssa.Call) string {
+	name := calleeName(instr)
+
+	if n, ok := arities[name]; ok && (instr.Call.IsInvoke() || len(instr.Call.Args) != n) {
+		return ""
+	}
+	return name
 }
 
 // checkRSAGenerateKey checks if the [crypto/rsa.GenerateMultiPrimeKey] function is being used securely,
@@ -120,7 +494,21 @@ func checkGenerateMultiPrimeKey(pass *analysis.Pass, instr *ssa.Call) {
 
 	checkNPrimesForBits(pass, instr, nprimes, bits)
 
-	pass.Reportf(instr.Pos(), generateKeyMessage)
+	checkFIPSKey(pass, instr, nprimes, bits)
+
+	checkRecoveredKeyGeneration(pass, instr)
+	checkTestKeyLeak(pass, instr)
+
+	checkDiscardedKey(pass, instr)
+	checkIgnoredError(pass, instr)
+
+	checkPrecompute(pass, instr)
+
+	report(pass, generateKeyMessage, analysis.Diagnostic{
+		Pos:            instr.Pos(),
+		Message:        generateKeyMessage,
+		SuggestedFixes: generateKeyFix(pass, instr),
+	})
 }
 
 // checkGenerateKey checks if the [crypto/rsa.GenerateKey] function is being used securely.
@@ -133,41 +521,302 @@ func checkGenerateKey(pass *analysis.Pass, instr *ssa.Call) {
 	checkSecureRandomReader(pass, instr, random)
 
 	checkBits(pass, instr, bits)
+
+	checkFIPSKey(pass, instr, nil, bits)
+
+	checkKeySizeValidated(pass, instr, bits)
+
+	checkRecoveredKeyGeneration(pass, instr)
+	checkTestKeyLeak(pass, instr)
+
+	checkDiscardedKey(pass, instr)
+	checkIgnoredError(pass, instr)
+
+	checkPrecompute(pass, instr)
 }
 
 // checkEncryptPKCS1v15 checks if the [crypto/rsa.EncryptPKCS1v15] function is being used securely.
 func checkEncryptPKCS1v15(pass *analysis.Pass, instr *ssa.Call) {
 	checkSecureRandomReader(pass, instr, instr.Call.Args[0])
 
-	pass.Reportf(instr.Pos(), oaepMessage)
+	if !checkStoredCiphertext(pass, instr) {
+		report(pass, oaepMessage, analysis.Diagnostic{
+			Pos:            instr.Pos(),
+			Message:        oaepMessage,
+			SuggestedFixes: encryptOAEPFix(pass, instr),
+		})
+	}
+
+	checkFIPSEncryption(pass, instr)
+
+	checkBulkEncryption(pass, instr, instr.Call.Args[2])
+}
+
+// checkDecryptPKCS1v15 checks the usage of [crypto/rsa.DecryptPKCS1v15], and advises to use
+// [crypto/rsa.DecryptOAEP] instead, since errors, or the timing of errors, of PKCS #1 v1.5
+// decryption can be used as a Bleichenbacher padding oracle to decrypt ciphertexts. For
+// session keys, [crypto/rsa.DecryptPKCS1v15SessionKey] doesn't reveal padding errors.
+func checkDecryptPKCS1v15(pass *analysis.Pass, instr *ssa.Call) {
+	reportf(pass, instr.Pos(), paddingOracleMessage)
+
+	checkDecrypt(pass, instr, instr.Call.Args[2])
+}
+
+// checkSignPKCS1v15 checks the usage of [crypto/rsa.SignPKCS1v15], and advises to use
+// [crypto/rsa.SignPSS] instead, since PSS is randomized, and has a security proof.
+// Only signing is reported, since verifying legacy signatures is often unavoidable.
+func checkSignPKCS1v15(pass *analysis.Pass, instr *ssa.Call) {
+	reportf(pass, instr.Pos(), pssMessage)
+
+	if !checkUnhashedSignature(pass, instr) {
+		checkZeroHash(pass, instr, instr.Call.Args[2])
+	}
+
+	checkVariableHash(pass, instr, instr.Call.Args[2])
+
+	checkSignedDigest(pass, instr, instr.Call.Args[3])
+
+	checkDiscardedSignature(pass, instr)
+}
+
+// storeFunctions are functions that persist data to a file or database.
+var storeFunctions = []string{
+	"os.WriteFile",
+	"io.WriteString",
+	"(*os.File).Write",
+	"(*os.File).WriteString",
+	"(*database/sql.DB).Exec",
+	"(*database/sql.DB).ExecContext",
+	"(*database/sql.Tx).Exec",
+	"(*database/sql.Tx).ExecContext",
+	"(*database/sql.Stmt).Exec",
+	"(*database/sql.Stmt).ExecContext",
+}
+
+// checkStoredCiphertext checks if the ciphertext returned by [crypto/rsa.EncryptPKCS1v15] is
+// base64-encoded and then written to a file or database in the same function. Long-lived
+// ciphertexts can't easily be migrated to another scheme, so the finding is reported with
+// related information pointing to where the ciphertext is encoded and stored.
+//
+// It reports whether the finding was reported, which is only done when enabled.
+func checkStoredCiphertext(pass *analysis.Pass, instr *ssa.Call) bool {
+	if !configOf(pass).StoredCiphertext {
+		return false
+	}
+
+	for _, ref := range *instr.Referrers() {
+		ciphertext, ok := ref.(*ssa.Extract)
+		if !ok || ciphertext.Index != 0 {
+			continue
+		}
+
+		encode, ok := flowsTo(ciphertext, base64EncodeToString)
+		if !ok {
+			continue
+		}
+
+		store, ok := flowsTo(encode, storeFunctions...)
+		if !ok {
+			continue
+		}
+
+		report(pass, storedCiphertextMessage, analysis.Diagnostic{
+			Pos:     instr.Pos(),
+			Message: storedCiphertextMessage,
+			Related: []analysis.RelatedInformation{
+				{Pos: encode.Pos(), Message: "ciphertext is base64-encoded here"},
+				{Pos: store.Pos(), Message: "and stored here"},
+			},
+		})
+		return true
+	}
+
+	return false
+}
+
+// checkEncryptOAEP checks if the [crypto/rsa.EncryptOAEP] function is being used securely.
+func checkEncryptOAEP(pass *analysis.Pass, instr *ssa.Call) {
+	checkOAEPHash(pass, instr, instr.Call.Args[0])
+	checkVariableHash(pass, instr, instr.Call.Args[0])
+
+	checkBulkEncryption(pass, instr, instr.Call.Args[3])
+
+	checkEncryptInLoop(pass, instr)
+
+	checkOAEPMessageSize(pass, instr)
+}
+
+// checkEncryptInLoop checks if RSA encryption is performed in a loop over an unbounded
+// number of messages, such as a slice or channel that may be attacker-sized. RSA
+// operations are expensive, so this can become a resource exhaustion concern.
+func checkEncryptInLoop(pass *analysis.Pass, instr *ssa.Call) {
+	if !configOf(pass).EncryptInLoop {
+		return
+	}
+
+	if l, ok := innermostLoop(instr.Block()); ok && !l.bounded() {
+		reportf(pass, instr.Pos(), encryptInLoopMessage)
+	}
+}
+
+// checkBulkEncryption checks if the message being encrypted is read from a file or
+// stream (os.ReadFile, io.ReadAll) in the same function. RSA can only encrypt messages
+// smaller than the key size, and should only be used to wrap a symmetric key that
+// encrypts the actual data (hybrid encryption).
+func checkBulkEncryption(pass *analysis.Pass, instr *ssa.Call, msg ssa.Value) {
+	if !configOf(pass).BulkEncryption {
+		return
+	}
+
+	if _, ok := callTo(msg, osReadFile, ioReadAll); ok {
+		reportf(pass, instr.Pos(), bulkEncryptionMessage)
+	}
 }
 
 // run is the entry point for the analysis pass, and will be called once for each package
 // being analyzed. The SSA representation of the package is provided, and the analysis
 // should return a result value and an error (which should be nil if the analysis succeeded).
-func run(pass *analysis.Pass) (interface{}, error) {
+//
+// The checks look up the given configuration of the analyzer using [configOf].
+func run(pass *analysis.Pass, cfg *Config) (interface{}, error) {
+	passConfigs.Store(pass, cfg)
+	defer passConfigs.Delete(pass)
+
 	ir := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
 
+	var public map[*ssa.Function]bool
+	if cfg.PublicOnly {
+		public = publicFuncs(ir.SrcFuncs)
+	}
+
+	var funcs []*ssa.Function
+
 	for _, fn := range ir.SrcFuncs {
-		for _, b := range fn.Blocks {
-			for _, instr := range b.Instrs {
-				switch instr := instr.(type) {
-				case *ssa.Call:
-					switch instr.Call.Value.String() {
-					case generateMultiPrimeKey:
-						checkGenerateMultiPrimeKey(pass, instr)
-					case generateKey:
-						checkGenerateKey(pass, instr)
-					case encryptPKCS1v15:
-						checkEncryptPKCS1v15(pass, instr)
-					default:
-						// fmt.Println(instr.Call.Value.String())
-						continue
-					}
-				}
-			}
+		if cfg.PublicOnly && !public[fn] {
+			continue
 		}
+
+		if cfg.SkipBenchmarks && benchmark(pass, fn) {
+			continue
+		}
+
+		checkFunction(pass, fn)
+
+		funcs = append(funcs, fn)
 	}
 
+	checkPackage(pass, funcs)
+
 	return nil, nil
 }
+
+// checkPackage runs the checks that look at the given functions of the package together,
+// such as for keys used both to sign and to decrypt.
+//
+// A panic in a check is recovered and reported as an internal error for the package, as
+// by [checkFunction].
+func checkPackage(pass *analysis.Pass, funcs []*ssa.Function) {
+	if len(pass.Files) > 0 {
+		defer recoverInternalError(pass, pass.Pkg.Path(), pass.Files[0].Package)
+	}
+
+	if checkPackageHook != nil {
+		checkPackageHook(pass.Pkg)
+	}
+
+	checkKeyReuse(pass, funcs)
+	checkSuggestEdDSA(pass, funcs)
+}
+
+// recoverInternalError recovers from a panic in the checks of the given function or
+// package, and reports it as an internal error at the given position. It must be called
+// directly by a deferred call.
+//
+// Internal errors are reported directly, rather than by [report], so they're never
+// filtered: they don't belong to a rule that can be disabled or have a severity, and
+// hiding them, such as in an excluded or generated file, would hide that the code
+// wasn't checked.
+func recoverInternalError(pass *analysis.Pass, analyzed any, pos token.Pos) {
+	if r := recover(); r != nil {
+		pass.Report(analysis.Diagnostic{
+			Pos:      pos,
+			Category: internalErrorCategory,
+			Message:  fmt.Sprintf(internalErrorMessage, analyzed, pass.Fset.Position(pos), r),
+		})
+	}
+}
+
+// checkFunction runs the checks on the instructions of the given function.
+//
+// A panic in a check, such as on unusual SSA, is recovered and reported as an internal
+// error for the function, so that the rest of the package is still analyzed.
+func checkFunction(pass *analysis.Pass, fn *ssa.Function) {
+	defer recoverInternalError(pass, fn, fn.Pos())
+
+	if checkHook != nil {
+		checkHook(fn)
+	}
+
+	checkSignVerifyHashes(pass, fn)
+	checkInsecureSkipVerify(pass, fn)
+
+	cfg := configOf(pass)
+
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			switch instr := instr.(type) {
+			case *ssa.Alloc:
+				if cfg.KeyLiteral {
+					checkKeyLiteral(pass, instr)
+				}
+			case *ssa.Store:
+				checkPublicExponent(pass, instr)
+			case *ssa.BinOp:
+				checkHardcodedKeyCompare(pass, instr)
+			case *ssa.Call:
+				checkKeyRole(pass, instr)
+
+				switch checkedCallee(instr) {
+				case generateMultiPrimeKey:
+					checkGenerateMultiPrimeKey(pass, instr)
+				case generateKey:
+					checkGenerateKey(pass, instr)
+				case encryptPKCS1v15:
+					checkEncryptPKCS1v15(pass, instr)
+				case encryptOAEP:
+					checkEncryptOAEP(pass, instr)
+				case signPKCS1v15:
+					checkSignPKCS1v15(pass, instr)
+				case signPSS:
+					checkPSSSaltLength(pass, instr, instr.Call.Args[2], instr.Call.Args[4])
+					checkVariableHash(pass, instr, instr.Call.Args[2])
+					checkSignedDigest(pass, instr, instr.Call.Args[3])
+					checkDiscardedSignature(pass, instr)
+				case verifyPKCS1v15:
+					checkZeroHash(pass, instr, instr.Call.Args[1])
+					checkVariableHash(pass, instr, instr.Call.Args[1])
+				case verifyPSS:
+					checkVariableHash(pass, instr, instr.Call.Args[1])
+				case decryptPKCS1v15:
+					checkDecryptPKCS1v15(pass, instr)
+				case decryptOAEP:
+					checkOAEPHash(pass, instr, instr.Call.Args[0])
+					checkVariableHash(pass, instr, instr.Call.Args[0])
+					checkDecrypt(pass, instr, instr.Call.Args[3])
+				case reflectDeepEqual:
+					checkDeepEqual(pass, instr)
+				case bigIntCmp:
+					checkHardcodedKeyCompare(pass, instr)
+				case gobEncode:
+					checkGobPrivateKey(pass, instr)
+				case marshalPKCS1PrivateKey, marshalPKCS8PrivateKey:
+					checkPlaintextKey(pass, instr)
+				case encryptPEMBlock:
+					checkEncryptPEMBlock(pass, instr)
+				default:
+					continue
+				}
+			}
+		}
+	}
+}
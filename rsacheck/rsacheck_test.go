@@ -1,9 +1,17 @@
 package rsacheck
 
 import (
+	"errors"
+	"go/types"
+	"path/filepath"
+	"sync"
 	"testing"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
 )
 
 func TestVulnerable(t *testing.T) {
@@ -13,3 +21,654 @@ func TestVulnerable(t *testing.T) {
 func TestNotVulnerable(t *testing.T) {
 	analysistest.Run(t, analysistest.TestData(), Analyzer, "not-vulnerable")
 }
+
+func TestBulkEncryption(t *testing.T) {
+	setFlag(t, "bulk-encryption", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "bulk-encryption")
+}
+
+func TestHashMismatch(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "hash-mismatch")
+}
+
+func TestPooledReader(t *testing.T) {
+	setFlag(t, "pooled-reader", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "pooled-reader")
+}
+
+func TestEncryptInLoop(t *testing.T) {
+	setFlag(t, "encrypt-in-loop", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "encrypt-in-loop")
+}
+
+func TestClassifyReader(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.TrustedReaders = []string{"readers.HSMReader"}
+
+	want := map[string]ReaderKind{
+		"secureCryptoRand":     SecureCryptoRand,
+		"mathRand":             MathRand,
+		"deterministicBytes":   Deterministic,
+		"deterministicStrings": Deterministic,
+		"deterministicChaCha8": Deterministic,
+		"customTrusted":        CustomTrusted,
+		"unknown":              Unknown,
+	}
+
+	classify := &analysis.Analyzer{
+		Name:     "classify",
+		Doc:      "classify the readers given to rsa.GenerateKey",
+		Requires: []*analysis.Analyzer{buildssa.Analyzer},
+		Run: func(pass *analysis.Pass) (interface{}, error) {
+			ir := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+
+			for _, fn := range ir.SrcFuncs {
+				for _, b := range fn.Blocks {
+					for _, instr := range b.Instrs {
+						call, ok := instr.(*ssa.Call)
+						if !ok || call.Call.Value.String() != generateKey {
+							continue
+						}
+
+						if got := cfg.ClassifyReader(call.Call.Args[0]); got != want[fn.Name()] {
+							t.Errorf("%s: got %v, want %v", fn.Name(), got, want[fn.Name()])
+						}
+
+						// Without a configuration, no custom readers are trusted.
+						wantDefault := want[fn.Name()]
+						if wantDefault == CustomTrusted {
+							wantDefault = Unknown
+						}
+						if got := ClassifyReader(call.Call.Args[0]); got != wantDefault {
+							t.Errorf("%s: got %v without a configuration, want %v", fn.Name(), got, wantDefault)
+						}
+						delete(want, fn.Name())
+					}
+				}
+			}
+
+			return nil, nil
+		},
+	}
+
+	analysistest.Run(t, analysistest.TestData(), classify, "readers")
+
+	for name := range want {
+		t.Errorf("%s: no rsa.GenerateKey call was classified", name)
+	}
+}
+
+func TestStoredCiphertext(t *testing.T) {
+	setFlag(t, "stored-ciphertext", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "stored-ciphertext")
+}
+
+func TestExponent(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "exponent")
+}
+
+func TestKeyDeepEqual(t *testing.T) {
+	setFlag(t, "key-deep-equal", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "key-deep-equal")
+}
+
+func TestFIPS(t *testing.T) {
+	t.Setenv("GOEXPERIMENT", "boringcrypto")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "fips")
+}
+
+func TestFIPS140(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "fips140")
+}
+
+func TestUnvalidatedKeySize(t *testing.T) {
+	setFlag(t, "unvalidated-key-size", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "unvalidated-key-size")
+}
+
+func TestPublicOnly(t *testing.T) {
+	setFlag(t, "public-only", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "public-only")
+}
+
+func TestUnauthenticatedDecrypt(t *testing.T) {
+	setFlag(t, "unauthenticated-decrypt", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "unauthenticated-decrypt")
+}
+
+func TestSeededRand(t *testing.T) {
+	setFlag(t, "seeded-rand", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "seeded-rand")
+}
+
+func TestSkipBenchmarks(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "benchmarks")
+
+	setFlag(t, "skip-benchmarks", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "skip-benchmarks")
+}
+
+func TestHashSizeBits(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "hash-size")
+}
+
+func TestWeakKeyUse(t *testing.T) {
+	results := analysistest.Run(t, analysistest.TestData(), Analyzer, "weak-key-use")
+
+	// Findings where the key is used link to where it's generated.
+	var uses int
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			if len(diag.Related) == 0 {
+				continue
+			}
+			uses++
+
+			if len(diag.Related) != 2 {
+				t.Fatalf("expected 2 related positions, got %d", len(diag.Related))
+			}
+
+			fset := result.Pass.Fset
+			generated := fset.Position(diag.Related[0].Pos)
+			used := fset.Position(diag.Related[1].Pos)
+
+			if generated.Line >= used.Line {
+				t.Errorf("expected key to be generated before it's used, got %v and %v", generated, used)
+			}
+
+			if used != fset.Position(diag.Pos) {
+				t.Errorf("expected use at %v, got %v", fset.Position(diag.Pos), used)
+			}
+		}
+	}
+
+	if uses != 2 {
+		t.Errorf("expected 2 findings with related information, got %d", uses)
+	}
+}
+
+func TestMutableReader(t *testing.T) {
+	setFlag(t, "mutable-reader", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "mutable-reader")
+}
+
+func TestVariableHash(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "variable-hash")
+}
+
+func TestAllowWeak(t *testing.T) {
+	t.Setenv("GOFLAGS", "-mod=mod -tags=rsalint_allow_weak")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "allow-weak")
+}
+
+func TestPossibleBits(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "possible-bits")
+}
+
+func TestRunOnPackages(t *testing.T) {
+	cfg := &packages.Config{
+		Mode: packages.LoadAllSyntax,
+		Dir:  filepath.Join("testdata", "src", "bulk-encryption"),
+	}
+
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Runs with different flags don't share the analyzer's configuration, and can
+	// happen concurrently.
+	var (
+		wg      sync.WaitGroup
+		results [2][]Finding
+		errs    [2]error
+	)
+	for i, flags := range []map[string]string{{"bulk-encryption": "true"}, nil} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = RunOnPackages(pkgs, Options{Flags: flags})
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs[:]...); err != nil {
+		t.Fatal(err)
+	}
+
+	findings := results[0]
+
+	rules := map[string]int{}
+	for _, f := range findings {
+		if filepath.Base(f.Pos.Filename) != "main.go" || f.Pos.Line == 0 {
+			t.Errorf("unexpected position %v", f.Pos)
+		}
+		rules[f.RuleID]++
+	}
+
+	if rules[bulkEncryptionRule.ID] == 0 {
+		t.Errorf("expected %s findings, got %v", bulkEncryptionRule.ID, findings)
+	}
+
+	for _, f := range results[1] {
+		if f.RuleID == bulkEncryptionRule.ID {
+			t.Errorf("unexpected %s finding without -bulk-encryption: %v", f.RuleID, f)
+		}
+	}
+
+	// The global analyzer's flags aren't set by runs.
+	if Analyzer.Flags.Lookup("bulk-encryption").Value.String() != "false" {
+		t.Error("expected -bulk-encryption of the global analyzer to be unchanged")
+	}
+
+	if _, err := RunOnPackages(pkgs, Options{Flags: map[string]string{"unknown": "true"}}); err == nil {
+		t.Error("expected an error for an unknown flag")
+	}
+}
+
+func TestNonCryptoDigest(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "non-crypto-digest")
+}
+
+func TestRecoveredKeyGen(t *testing.T) {
+	setFlag(t, "recovered-keygen", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "recovered-keygen")
+}
+
+func TestUnmarshaledBits(t *testing.T) {
+	setFlag(t, "unmarshaled-bits", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "unmarshaled-bits")
+}
+
+func TestHardcodedKeyCompare(t *testing.T) {
+	setFlag(t, "hardcoded-key-compare", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "hardcoded-key-compare")
+}
+
+func TestWasmReader(t *testing.T) {
+	t.Setenv("GOOS", "js")
+	t.Setenv("GOARCH", "wasm")
+
+	setFlag(t, "wasm-reader", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "wasm-reader")
+}
+
+func TestGobPrivateKey(t *testing.T) {
+	setFlag(t, "gob-private-key", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "gob-private-key")
+}
+
+func TestSwappedKeyRole(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "swapped-key-role")
+}
+
+func TestStrictRand(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "strict-rand")
+
+	// Unknown readers are only reported in strict mode, but known weak readers still are.
+	setFlag(t, "strict-rand", "false")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "non-strict-rand")
+}
+
+func TestShiftedBits(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "shifted-bits")
+}
+
+func TestTestHelperReader(t *testing.T) {
+	setFlag(t, "test-helper-reader", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "test-helper-reader")
+}
+
+func TestRecoveredPanic(t *testing.T) {
+	checkHook = func(fn *ssa.Function) {
+		if fn.Name() == "Panics" {
+			panic("unexpected SSA")
+		}
+	}
+	t.Cleanup(func() { checkHook = nil })
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "recovered-panic")
+}
+
+func TestRecoveredPackagePanic(t *testing.T) {
+	checkPackageHook = func(pkg *types.Package) {
+		panic("unexpected SSA")
+	}
+	t.Cleanup(func() { checkPackageHook = nil })
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "recovered-package-panic")
+}
+
+func TestNilReader(t *testing.T) {
+	setFlag(t, "nil-reader", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "nil-reader")
+}
+
+func TestBigExponent(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "big-exponent")
+}
+
+func TestZeroHash(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "zero-hash")
+}
+
+func TestTestKeyLeak(t *testing.T) {
+	setFlag(t, "test-key-leak", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "test-key-leak")
+}
+
+func TestSignPKCS1v15(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "sign-pkcs1v15")
+}
+
+func TestOAEPMessageSize(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "oaep-message-size")
+}
+
+func TestLocalReader(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "local-reader")
+}
+
+func TestVariableBits(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "variable-bits")
+}
+
+func TestMinBits(t *testing.T) {
+	setFlag(t, "min-bits", "4096")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "min-bits")
+}
+
+func TestLongValidity(t *testing.T) {
+	setFlag(t, "long-validity", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "long-validity")
+}
+
+func TestDiscardedKey(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "discarded-key")
+}
+
+func TestDisabledChecks(t *testing.T) {
+	setFlag(t, "disable-random", "true")
+	setFlag(t, "disable-pkcs1v15", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "disabled-checks")
+}
+
+func TestDiscardedSignature(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "discarded-signature")
+}
+
+func TestEncryptOAEPFix(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "encrypt-oaep-fix")
+}
+
+func TestGenerateKeyFix(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "generate-key-fix")
+}
+
+func TestFeatureFlags(t *testing.T) {
+	setFlag(t, "feature-flags", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "feature-flags")
+}
+
+func TestBitsFix(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), Analyzer, "bits-fix")
+}
+
+func TestNolint(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "nolint")
+}
+
+func TestPaddingOracle(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "padding-oracle")
+}
+
+func TestInsecureSkipVerify(t *testing.T) {
+	setFlag(t, "insecure-skip-verify", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "insecure-skip-verify")
+}
+
+func TestPredictableSeed(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "predictable-seed")
+}
+
+func TestGeneratedBits(t *testing.T) {
+	setFlag(t, "generated-bits", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "generated-bits")
+}
+
+func TestKeyLiteral(t *testing.T) {
+	setFlag(t, "key-literal", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "key-literal")
+}
+
+func TestShowRuleIDs(t *testing.T) {
+	setFlag(t, "show-rule-ids", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "rule-ids")
+}
+
+func TestIndirectCall(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "indirect-call")
+}
+
+func TestPSSSaltLength(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "pss-salt-length")
+}
+
+func TestKeyReuse(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "shared-key", "separated-key")
+}
+
+func TestExclude(t *testing.T) {
+	setFlag(t, "exclude", "*_legacy.go, vendored")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "exclude/...")
+}
+
+func TestExcludeMatch(t *testing.T) {
+	tests := []struct {
+		globs    string
+		filename string
+		want     bool
+	}{
+		{"*_legacy.go", "/src/app/keys_legacy.go", true},
+		{"*_legacy.go", "/src/app/keys.go", false},
+		{"legacy/*.go", "/src/app/legacy/keys.go", true},
+		{"legacy/*.go", "/src/app/legacy/old/keys.go", false},
+		{"legacy", "/src/app/legacy/old/keys.go", true},
+		{"legacy", "/src/app/legacyx/keys.go", false},
+		{"vendor/*", "/src/app/vendor/example.com/keys.go", true},
+		{"/src/app/internal/*", "/src/app/internal/keys.go", true},
+		{"/src/app/internal/*", "/src/other/app/internal/keys.go", false},
+		{"keys.go,*.pb.go", "/src/app/keys.pb.go", true},
+		{"keys.go,*.pb.go", "/src/app/main.go", false},
+		{"internal/**", "/src/app/internal/crypto/keys.go", true},
+		{"internal/**/*_legacy.go", "/src/app/internal/crypto/keys_legacy.go", true},
+		{"internal/**/*_legacy.go", "/src/app/internal/crypto/keys.go", false},
+		{"/src/app/**/legacy", "/src/app/internal/legacy/keys.go", true},
+	}
+
+	for _, test := range tests {
+		var globs globList
+		if err := globs.Set(test.globs); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := globs.match(test.filename); got != test.want {
+			t.Errorf("%q matching %s: got %v, want %v", test.globs, test.filename, got, test.want)
+		}
+	}
+
+	var globs globList
+	if err := globs.Set("legacy/["); err == nil {
+		t.Error("expected an error for a malformed glob")
+	}
+}
+
+func TestGeneratedFiles(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "generated-files")
+}
+
+func TestLintGenerated(t *testing.T) {
+	setFlag(t, "lint-generated", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "lint-generated")
+}
+
+func TestMultiPrimeGaps(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "multiprime-gaps")
+}
+
+func TestMaxPrimesForBits(t *testing.T) {
+	tests := []struct {
+		bits int
+		want int
+		ok   bool
+	}{
+		{0, 0, false},
+		{512, 3, true},
+		{1024, 3, true},
+		{2048, 3, true},
+		{3072, 3, true},
+		{4096, 4, true},
+		{6144, 4, true},
+		{8192, 5, true},
+		{16384, 5, true},
+	}
+
+	for _, test := range tests {
+		got, ok := maxPrimesForBits(test.bits)
+		if got != test.want || ok != test.ok {
+			t.Errorf("maxPrimesForBits(%d) = %d, %v, want %d, %v", test.bits, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestNewAnalyzer(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.MinBits = 4096
+	cfg.BulkEncryption = true
+	cfg.Disable = []string{"pkcs1v15"}
+
+	a := NewAnalyzer(cfg)
+
+	// The analyzer has its own copy of the configuration.
+	cfg.Disable[0] = "bits"
+
+	analysistest.Run(t, analysistest.TestData(), a, "custom-config")
+
+	if f := a.Flags.Lookup("disable-pkcs1v15"); f.Value.String() != "true" {
+		t.Errorf("expected -disable-pkcs1v15 to be set by the configuration, got %s", f.Value)
+	}
+
+	// The global analyzer isn't affected.
+	for name, want := range map[string]string{"min-bits": "2048", "bulk-encryption": "false", "disable-pkcs1v15": "false"} {
+		if got := Analyzer.Flags.Lookup(name).Value.String(); got != want {
+			t.Errorf("expected -%s of the global analyzer to be %s, got %s", name, want, got)
+		}
+	}
+}
+
+func TestTimeSeed(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "time-seed")
+}
+
+func TestCheckErrors(t *testing.T) {
+	setFlag(t, "check-errors", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "check-errors")
+}
+
+func TestPrecompute(t *testing.T) {
+	setFlag(t, "perf", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "precompute")
+}
+
+func TestPlaintextKey(t *testing.T) {
+	setFlag(t, "plaintext-key", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "plaintext-key")
+}
+
+func TestEncryptPEMBlock(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "encrypt-pem-block")
+}
+
+func TestMinSeverity(t *testing.T) {
+	setFlag(t, "min-severity", "warning")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "min-severity")
+}
+
+func TestMinSeverityInvalid(t *testing.T) {
+	if err := Analyzer.Flags.Set("min-severity", "critical"); err == nil {
+		t.Fatal("expected an error for an unknown severity")
+	}
+
+	if got := Analyzer.Flags.Lookup("min-severity").Value.String(); got != string(SeverityInfo) {
+		t.Errorf("expected the severity to be unchanged, got %q", got)
+	}
+}
+
+func TestSuggestEdDSA(t *testing.T) {
+	setFlag(t, "suggest-eddsa", "true")
+
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "suggest-eddsa")
+}
+
+func TestFoldedBits(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "folded-bits")
+}
+
+func TestShadowedRSA(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "shadowed-rsa")
+}
+
+// setFlag sets one of the analyzer's flags for the duration of the test.
+func setFlag(t *testing.T, name, value string) {
+	t.Helper()
+
+	f := Analyzer.Flags.Lookup(name)
+	if f == nil {
+		t.Fatalf("unknown flag %q", name)
+	}
+
+	old := f.Value.String()
+	if err := f.Value.Set(value); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		f.Value.Set(old)
+	})
+}
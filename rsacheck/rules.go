@@ -0,0 +1,720 @@
+package rsacheck
+
+import (
+	"fmt"
+	"go/token"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Rule is a check performed by the analyzer. Each diagnostic reported by the analyzer
+// belongs to a rule, whose ID is used as the diagnostic's category.
+type Rule struct {
+	// ID is a stable identifier for the rule, such as "RSA001".
+	ID string
+
+	// Category groups related rules, such as "weak-random" or "deprecated".
+	Category string
+
+	// Severity is the default severity of findings of the rule: "error" for weaknesses
+	// that can be exploited, "warning" for misuse that should be fixed, and "info" for
+	// advisories, such as preferring PSS, or performance improvements.
+	Severity Severity
+
+	// Confidence is how likely a finding of the rule is a real issue: "high" for
+	// findings that are almost always correct, "medium" for findings that rely on
+	// heuristics, and "low" for findings that only point out code to review.
+	Confidence string
+
+	// CWE is the number of the Common Weakness Enumeration entry that findings of the
+	// rule are an instance of, such as 326 for CWE-326: Inadequate Encryption Strength.
+	CWE int
+
+	// References are URLs of documentation or standards explaining the rule.
+	References []string
+
+	// Remediation is a snippet of Go code showing how to fix a finding of the rule.
+	Remediation string
+}
+
+// Messages returns the message templates reported by the rule, sorted, which may
+// have fmt verbs such as %v that are replaced by the details of each finding.
+func (r *Rule) Messages() []string {
+	var messages []string
+	for message, rule := range messageRules {
+		if rule == r {
+			messages = append(messages, message)
+		}
+	}
+	slices.Sort(messages)
+	return messages
+}
+
+// Severity of the findings of a rule.
+type Severity string
+
+// Severities that rules can have, from the most to the least severe.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// rank returns the rank of the severity, which is higher for more severe findings,
+// or -1 if the severity isn't known.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityInfo:
+		return 0
+	case SeverityWarning:
+		return 1
+	case SeverityError:
+		return 2
+	}
+	return -1
+}
+
+// Valid reports whether the severity is one of the known severities.
+func (s Severity) Valid() bool {
+	return s.rank() >= 0
+}
+
+// severityFlag is the -min-severity flag.
+type severityFlag Severity
+
+func (f *severityFlag) String() string {
+	return string(*f)
+}
+
+func (f *severityFlag) Set(s string) error {
+	if !Severity(s).Valid() {
+		return fmt.Errorf("unknown severity %q: must be error, warning, or info", s)
+	}
+	*f = severityFlag(s)
+	return nil
+}
+
+// Confidences that rules can have.
+const (
+	ConfidenceHigh   = "high"
+	ConfidenceMedium = "medium"
+	ConfidenceLow    = "low"
+)
+
+// RulesVersion is the version of the rule set checked by the analyzer. It's incremented
+// whenever rules are added or removed, or change what they report, so that results saved
+// by a previous version, such as baselines, are invalidated.
+const RulesVersion = 44
+
+// Rules checked by the analyzer.
+var (
+	weakRandomRule = &Rule{
+		ID:          "RSA001",
+		Category:    "weak-random",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         338,
+		References:  []string{"https://pkg.go.dev/crypto/rand#Reader"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 2048) // crypto/rand",
+	}
+	weakKeySizeRule = &Rule{
+		ID:          "RSA002",
+		Category:    "weak-key",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         326,
+		References:  []string{"https://nvlpubs.nist.gov/nistpubs/SpecialPublications/NIST.SP.800-57pt1r5.pdf"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 2048)",
+	}
+	weakPrimeCountRule = &Rule{
+		ID:          "RSA003",
+		Category:    "weak-key",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         326,
+		References:  []string{"http://www.cacr.math.uwaterloo.ca/techreports/2006/cacr2006-16.pdf"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 2048)",
+	}
+	multiPrimeRule = &Rule{
+		ID:          "RSA004",
+		Category:    "deprecated",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceHigh,
+		CWE:         477,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#GenerateMultiPrimeKey"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 2048)",
+	}
+	pkcs1v15EncryptRule = &Rule{
+		ID:          "RSA005",
+		Category:    "weak-encryption",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         780,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#EncryptOAEP", "https://www.rfc-editor.org/rfc/rfc8017#section-7.1"},
+		Remediation: "ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, msg, nil)",
+	}
+	bulkEncryptionRule = &Rule{
+		ID:          "RSA006",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         327,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#EncryptOAEP"},
+		Remediation: "ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, aesKey, nil) // encrypt the data with AES-GCM",
+	}
+	hashMismatchRule = &Rule{
+		ID:          "RSA007",
+		Category:    "misuse",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         347,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#VerifyPSS", "https://pkg.go.dev/crypto/rsa#DecryptOAEP"},
+		Remediation: "err := rsa.VerifyPSS(pub, crypto.SHA256, digest, sig, nil) // the hash used to sign",
+	}
+	pooledReaderRule = &Rule{
+		ID:          "RSA008",
+		Category:    "weak-random",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceLow,
+		CWE:         338,
+		References:  []string{"https://pkg.go.dev/crypto/rand#Reader"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 2048) // crypto/rand",
+	}
+	encryptInLoopRule = &Rule{
+		ID:          "RSA009",
+		Category:    "performance",
+		Severity:    SeverityInfo,
+		Confidence:  ConfidenceLow,
+		CWE:         400,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#EncryptOAEP"},
+		Remediation: "ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, aesKey, nil) // once, then encrypt messages with AES-GCM",
+	}
+	smallExponentRule = &Rule{
+		ID:          "RSA010",
+		Category:    "weak-key",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         326,
+		References:  []string{"https://www.rfc-editor.org/rfc/rfc8017#section-3.1"},
+		Remediation: "key := &rsa.PublicKey{N: n, E: 65537}",
+	}
+	keyDeepEqualRule = &Rule{
+		ID:          "RSA011",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceHigh,
+		CWE:         208,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#PrivateKey.Equal"},
+		Remediation: "equal := key.Equal(other)",
+	}
+	fipsRule = &Rule{
+		ID:          "RSA012",
+		Category:    "fips",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         327,
+		References:  []string{"https://go.dev/doc/security/fips140"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 2048)",
+	}
+	keySizeRule = &Rule{
+		ID:          "RSA013",
+		Category:    "weak-key",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         326,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#PublicKey.Size"},
+		Remediation: "if key.N.BitLen() < 2048 {\n\treturn errors.New(\"key is too small\")\n}",
+	}
+	unauthenticatedDecryptRule = &Rule{
+		ID:          "RSA014",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         345,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#DecryptOAEP"},
+		Remediation: "if err := rsa.VerifyPSS(pub, crypto.SHA256, digest, sig, nil); err != nil {\n\treturn err\n}\nplaintext, err := rsa.DecryptOAEP(sha256.New(), nil, key, ciphertext, nil)",
+	}
+	mutableReaderRule = &Rule{
+		ID:          "RSA015",
+		Category:    "weak-random",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         338,
+		References:  []string{"https://pkg.go.dev/crypto/rand#Reader"},
+		Remediation: "func GenerateKey(random io.Reader) (*rsa.PrivateKey, error) {\n\treturn rsa.GenerateKey(random, 2048)\n}",
+	}
+	variableHashRule = &Rule{
+		ID:          "RSA016",
+		Category:    "weak-hash",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         328,
+		References:  []string{"https://pkg.go.dev/crypto#Hash"},
+		Remediation: "sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, nil)",
+	}
+	nonCryptoDigestRule = &Rule{
+		ID:          "RSA017",
+		Category:    "weak-hash",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         328,
+		References:  []string{"https://pkg.go.dev/crypto/sha256"},
+		Remediation: "digest := sha256.Sum256(msg)\nsig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)",
+	}
+	recoveredKeyGenRule = &Rule{
+		ID:          "RSA018",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         755,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#GenerateKey"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 2048)\nif err != nil {\n\treturn nil, err\n}",
+	}
+	unmarshaledBitsRule = &Rule{
+		ID:          "RSA019",
+		Category:    "weak-key",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         326,
+		References:  []string{"https://nvlpubs.nist.gov/nistpubs/SpecialPublications/NIST.SP.800-57pt1r5.pdf"},
+		Remediation: "if cfg.Bits < 2048 {\n\treturn fmt.Errorf(\"key size %d is too small\", cfg.Bits)\n}",
+	}
+	unhashedSignatureRule = &Rule{
+		ID:          "RSA020",
+		Category:    "misuse",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         347,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#SignPKCS1v15"},
+		Remediation: "digest := sha256.Sum256(msg)\nsig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])",
+	}
+	hardcodedKeyCompareRule = &Rule{
+		ID:          "RSA021",
+		Category:    "hardcoded-key",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceLow,
+		CWE:         321,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#PublicKey.Equal"},
+		Remediation: "trusted := key.Equal(loadedTrustedKey)",
+	}
+	gobPrivateKeyRule = &Rule{
+		ID:          "RSA022",
+		Category:    "key-storage",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         312,
+		References:  []string{"https://pkg.go.dev/crypto/x509#MarshalPKCS8PrivateKey"},
+		Remediation: "der, err := x509.MarshalPKCS8PrivateKey(key) // then encrypt der",
+	}
+	swappedKeyRoleRule = &Rule{
+		ID:          "RSA023",
+		Category:    "misuse",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         320,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#PrivateKey"},
+		Remediation: "ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &key.PublicKey, msg, nil)",
+	}
+	testHelperReaderRule = &Rule{
+		ID:          "RSA024",
+		Category:    "weak-random",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         338,
+		References:  []string{"https://pkg.go.dev/crypto/rand#Reader"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 2048) // crypto/rand",
+	}
+	nilReaderRule = &Rule{
+		ID:          "RSA025",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceLow,
+		CWE:         476,
+		References:  []string{"https://pkg.go.dev/crypto/rand#Reader"},
+		Remediation: "if random == nil {\n\trandom = rand.Reader\n}",
+	}
+	oaepHashRule = &Rule{
+		ID:          "RSA026",
+		Category:    "weak-hash",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         328,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#EncryptOAEP"},
+		Remediation: "ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, msg, nil)",
+	}
+	zeroHashRule = &Rule{
+		ID:          "RSA027",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         347,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#VerifyPKCS1v15"},
+		Remediation: "err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig)",
+	}
+	testKeyLeakRule = &Rule{
+		ID:          "RSA028",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         321,
+		References:  []string{"https://pkg.go.dev/testing#hdr-Main"},
+		Remediation: "var testKey *rsa.PrivateKey // declared in a _test.go file",
+	}
+	pkcs1v15SignRule = &Rule{
+		ID:          "RSA029",
+		Category:    "advisory",
+		Severity:    SeverityInfo,
+		Confidence:  ConfidenceLow,
+		CWE:         327,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#SignPSS"},
+		Remediation: "sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)",
+	}
+	oaepMessageSizeRule = &Rule{
+		ID:          "RSA030",
+		Category:    "misuse",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         131,
+		References:  []string{"https://www.rfc-editor.org/rfc/rfc8017#section-7.1.1"},
+		Remediation: "ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, aesKey, nil) // encrypt the message with AES-GCM",
+	}
+	longValidityRule = &Rule{
+		ID:          "RSA031",
+		Category:    "weak-key",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceLow,
+		CWE:         326,
+		References:  []string{"https://nvlpubs.nist.gov/nistpubs/SpecialPublications/NIST.SP.800-57pt1r5.pdf"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 3072)",
+	}
+	discardedKeyRule = &Rule{
+		ID:          "RSA032",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceHigh,
+		CWE:         563,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#GenerateKey"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 2048) // generated once",
+	}
+	discardedSignatureRule = &Rule{
+		ID:          "RSA033",
+		Category:    "advisory",
+		Severity:    SeverityInfo,
+		Confidence:  ConfidenceMedium,
+		CWE:         563,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#SignPSS"},
+		Remediation: "sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)\nif err != nil {\n\treturn nil, err\n}\nreturn sig, nil",
+	}
+	featureFlagBitsRule = &Rule{
+		ID:          "RSA034",
+		Category:    "weak-key",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceMedium,
+		CWE:         326,
+		References:  []string{"https://nvlpubs.nist.gov/nistpubs/SpecialPublications/NIST.SP.800-57pt1r5.pdf"},
+		Remediation: "var legacyMode = false",
+	}
+	paddingOracleRule = &Rule{
+		ID:          "RSA035",
+		Category:    "weak-encryption",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceMedium,
+		CWE:         203,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#DecryptPKCS1v15SessionKey", "https://www.rfc-editor.org/rfc/rfc8017#section-7.2"},
+		Remediation: "plaintext, err := rsa.DecryptOAEP(sha256.New(), nil, key, ciphertext, nil)",
+	}
+	insecureSkipVerifyRule = &Rule{
+		ID:          "RSA036",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceLow,
+		CWE:         295,
+		References:  []string{"https://pkg.go.dev/crypto/tls#Config"},
+		Remediation: "config := &tls.Config{Certificates: []tls.Certificate{cert}, RootCAs: pool}",
+	}
+	predictableSeedRule = &Rule{
+		ID:          "RSA037",
+		Category:    "weak-random",
+		Severity:    SeverityError,
+		Confidence:  ConfidenceHigh,
+		CWE:         337,
+		References:  []string{"https://pkg.go.dev/math/rand#NewSource", "https://pkg.go.dev/crypto/rand#Reader"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 2048) // crypto/rand",
+	}
+	generatedBitsRule = &Rule{
+		ID:          "RSA038",
+		Category:    "advisory",
+		Severity:    SeverityInfo,
+		Confidence:  ConfidenceLow,
+		CWE:         326,
+		References:  []string{"https://go.dev/blog/generate"},
+		Remediation: "const keyBits = 2048 // declared in a file written by hand",
+	}
+	keyLiteralRule = &Rule{
+		ID:          "RSA039",
+		Category:    "weak-key",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         326,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#PublicKey.Size"},
+		Remediation: "key := &rsa.PublicKey{N: n, E: e}\nif key.N.BitLen() < 2048 {\n\treturn nil, errors.New(\"key is too small\")\n}",
+	}
+	pssSaltLengthRule = &Rule{
+		ID:          "RSA040",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceHigh,
+		CWE:         326,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#PSSOptions", "https://www.rfc-editor.org/rfc/rfc8017#section-9.1"},
+		Remediation: "sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})",
+	}
+	keyReuseRule = &Rule{
+		ID:          "RSA041",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         323,
+		References:  []string{"https://csrc.nist.gov/pubs/sp/800/57/pt1/r5/final"},
+		Remediation: "signingKey, err := rsa.GenerateKey(rand.Reader, 3072)\n...\ndecryptionKey, err := rsa.GenerateKey(rand.Reader, 3072)",
+	}
+	ignoredErrorRule = &Rule{
+		ID:          "RSA042",
+		Category:    "misuse",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceHigh,
+		CWE:         252,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#GenerateKey"},
+		Remediation: "key, err := rsa.GenerateKey(rand.Reader, 2048)\nif err != nil {\n\treturn nil, err\n}",
+	}
+	precomputeRule = &Rule{
+		ID:          "RSA043",
+		Category:    "performance",
+		Severity:    SeverityInfo,
+		Confidence:  ConfidenceLow,
+		CWE:         1176,
+		References:  []string{"https://pkg.go.dev/crypto/rsa#PrivateKey.Precompute"},
+		Remediation: "key.Precompute()\nfor _, msg := range msgs {\n\tsig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest(msg), nil)\n\t...\n}",
+	}
+	plaintextKeyRule = &Rule{
+		ID:          "RSA044",
+		Category:    "key-storage",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceMedium,
+		CWE:         312,
+		References:  []string{"https://pkg.go.dev/crypto/x509#MarshalPKCS8PrivateKey"},
+		Remediation: "der, err := x509.MarshalPKCS8PrivateKey(key)\n...\nsealed := aead.Seal(nonce, nonce, der, nil)\nerr = os.WriteFile(\"key.enc\", sealed, 0o600)",
+	}
+	encryptPEMBlockRule = &Rule{
+		ID:          "RSA045",
+		Category:    "deprecated",
+		Severity:    SeverityWarning,
+		Confidence:  ConfidenceHigh,
+		CWE:         327,
+		References:  []string{"https://pkg.go.dev/crypto/x509#EncryptPEMBlock"},
+		Remediation: "der, err := x509.MarshalPKCS8PrivateKey(key)\n...\nsealed := aead.Seal(nonce, nonce, der, nil) // with a key derived using scrypt or argon2",
+	}
+	suggestEdDSARule = &Rule{
+		ID:          "RSA046",
+		Category:    "advisory",
+		Severity:    SeverityInfo,
+		Confidence:  ConfidenceLow,
+		CWE:         1176,
+		References:  []string{"https://pkg.go.dev/crypto/ed25519#GenerateKey"},
+		Remediation: "pub, priv, err := ed25519.GenerateKey(rand.Reader)\n...\nsig := ed25519.Sign(priv, msg)",
+	}
+)
+
+// Rules is the list of all rules checked by the analyzer, ordered by ID.
+var Rules = []*Rule{
+	weakRandomRule,
+	weakKeySizeRule,
+	weakPrimeCountRule,
+	multiPrimeRule,
+	pkcs1v15EncryptRule,
+	bulkEncryptionRule,
+	hashMismatchRule,
+	pooledReaderRule,
+	encryptInLoopRule,
+	smallExponentRule,
+	keyDeepEqualRule,
+	fipsRule,
+	keySizeRule,
+	unauthenticatedDecryptRule,
+	mutableReaderRule,
+	variableHashRule,
+	nonCryptoDigestRule,
+	recoveredKeyGenRule,
+	unmarshaledBitsRule,
+	unhashedSignatureRule,
+	hardcodedKeyCompareRule,
+	gobPrivateKeyRule,
+	swappedKeyRoleRule,
+	testHelperReaderRule,
+	nilReaderRule,
+	oaepHashRule,
+	zeroHashRule,
+	testKeyLeakRule,
+	pkcs1v15SignRule,
+	oaepMessageSizeRule,
+	longValidityRule,
+	discardedKeyRule,
+	discardedSignatureRule,
+	featureFlagBitsRule,
+	paddingOracleRule,
+	insecureSkipVerifyRule,
+	predictableSeedRule,
+	generatedBitsRule,
+	keyLiteralRule,
+	pssSaltLengthRule,
+	keyReuseRule,
+	ignoredErrorRule,
+	precomputeRule,
+	plaintextKeyRule,
+	encryptPEMBlockRule,
+	suggestEdDSARule,
+}
+
+// checkGroup is a group of related rules that can be disabled together using the
+// analyzer's -disable-<name> flag, such as -disable-random, so that teams can adopt the
+// analyzer incrementally.
+type checkGroup struct {
+	name  string
+	doc   string
+	rules []*Rule
+}
+
+// checkGroups are the groups of rules that can be disabled.
+var checkGroups = []*checkGroup{
+	{name: "random", doc: "checks of random readers", rules: []*Rule{weakRandomRule, pooledReaderRule, mutableReaderRule, testHelperReaderRule, nilReaderRule, predictableSeedRule}},
+	{name: "bits", doc: "checks of the number of bits of keys", rules: []*Rule{weakKeySizeRule, keySizeRule, unmarshaledBitsRule, longValidityRule, featureFlagBitsRule, generatedBitsRule, keyLiteralRule}},
+	{name: "primes", doc: "checks of the number of primes of keys", rules: []*Rule{weakPrimeCountRule}},
+	{name: "multiprime", doc: "reports of deprecated rsa.GenerateMultiPrimeKey calls", rules: []*Rule{multiPrimeRule}},
+	{name: "exponent", doc: "checks of public exponents", rules: []*Rule{smallExponentRule}},
+	{name: "pkcs1v15", doc: "reports of PKCS #1 v1.5 encryption, decryption, and signatures, which advise OAEP and PSS instead", rules: []*Rule{pkcs1v15EncryptRule, pkcs1v15SignRule, paddingOracleRule}},
+}
+
+// ruleDisabled reports whether the rule belongs to a group of rules that's disabled by
+// the configuration.
+func ruleDisabled(cfg *Config, rule *Rule) bool {
+	for _, group := range checkGroups {
+		if slices.Contains(cfg.Disable, group.name) && slices.Contains(group.rules, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// messageRules maps each message reported by the analyzer to its rule.
+var messageRules = map[string]*Rule{
+	randSourceLintMessage:         weakRandomRule,
+	mathRandMessage:               weakRandomRule,
+	mutableReaderMessage:          mutableReaderRule,
+	hardcodedKeyCompareMessage:    hardcodedKeyCompareRule,
+	unmarshaledBitsMessage:        unmarshaledBitsRule,
+	recoveredKeyGenMessage:        recoveredKeyGenRule,
+	wasmReaderMessage:             weakRandomRule,
+	testHelperReaderMessage:       testHelperReaderRule,
+	nilReaderMessage:              nilReaderRule,
+	testKeyLeakMessage:            testKeyLeakRule,
+	discardedKeyMessage:           discardedKeyRule,
+	unusedKeyMessage:              discardedKeyRule,
+	discardedSignatureMessage:     discardedSignatureRule,
+	seededRandMessage:             weakRandomRule,
+	predictableSeedMessage:        predictableSeedRule,
+	timeSeedMessage:               predictableSeedRule,
+	numberOfbitsLintMessage:       weakKeySizeRule,
+	hashSizeBitsMessage:           weakKeySizeRule,
+	weakKeyUseMessage:             weakKeySizeRule,
+	longValidityMessage:           longValidityRule,
+	possibleBitsMessage:           weakKeySizeRule,
+	featureFlagBitsMessage:        featureFlagBitsRule,
+	generatedBitsMessage:          generatedBitsRule,
+	keyLiteralBitsMessage:         keyLiteralRule,
+	keyLiteralMessage:             keyLiteralRule,
+	multipleOf8BitsMessage:        weakKeySizeRule,
+	numberOfPrimesLintMessage:     weakPrimeCountRule,
+	generateKeyMessage:            multiPrimeRule,
+	oaepMessage:                   pkcs1v15EncryptRule,
+	paddingOracleMessage:          paddingOracleRule,
+	insecureSkipVerifyMessage:     insecureSkipVerifyRule,
+	pssMessage:                    pkcs1v15SignRule,
+	pssSaltLengthMessage:          pssSaltLengthRule,
+	keyReuseMessage:               keyReuseRule,
+	suggestEdDSAMessage:           suggestEdDSARule,
+	ignoredErrorMessage:           ignoredErrorRule,
+	precomputeMessage:             precomputeRule,
+	plaintextKeyMessage:           plaintextKeyRule,
+	encryptPEMBlockMessage:        encryptPEMBlockRule,
+	storedCiphertextMessage:       pkcs1v15EncryptRule,
+	bulkEncryptionMessage:         bulkEncryptionRule,
+	variableHashMessage:           variableHashRule,
+	oaepHashMessage:               oaepHashRule,
+	oaepMessageSizeMessage:        oaepMessageSizeRule,
+	unhashedSignatureMessage:      unhashedSignatureRule,
+	zeroHashMessage:               zeroHashRule,
+	nonCryptoDigestMessage:        nonCryptoDigestRule,
+	hashMismatchMessage:           hashMismatchRule,
+	oaepHashMismatchMessage:       hashMismatchRule,
+	pooledReaderMessage:           pooledReaderRule,
+	encryptInLoopMessage:          encryptInLoopRule,
+	smallExponentMessage:          smallExponentRule,
+	brokenKeyMessage:              smallExponentRule,
+	deepEqualMessage:              keyDeepEqualRule,
+	fipsMessage:                   fipsRule,
+	unvalidatedKeySizeMessage:     keySizeRule,
+	unauthenticatedDecryptMessage: unauthenticatedDecryptRule,
+	gobPrivateKeyMessage:          gobPrivateKeyRule,
+	privateKeyAsPublicMessage:     swappedKeyRoleRule,
+	publicKeyAsPrivateMessage:     swappedKeyRoleRule,
+}
+
+// LookupRule returns the rule with the given ID.
+func LookupRule(id string) (*Rule, bool) {
+	for _, rule := range Rules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return nil, false
+}
+
+// reportf reports a diagnostic at the given position, formatting the message
+// according to the format specifier, which must be one of the analyzer's messages.
+func reportf(pass *analysis.Pass, pos token.Pos, format string, args ...any) {
+	report(pass, format, analysis.Diagnostic{
+		Pos:     pos,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// report reports the given diagnostic for the message format it was created with,
+// setting the diagnostic's category to the ID of the message's rule.
+//
+// With -show-rule-ids, the message is prefixed with the rule's ID, such as [RSA002], for
+// drivers that don't print the category, such as "go vet".
+//
+// Diagnostics of disabled rules, of rules less severe than -min-severity, in generated files unless -lint-generated is set, in
+// files excluded using -exclude or that require the rsalint_allow_weak build tag, and on
+// lines with a //nolint:rsalint comment, are suppressed.
+func report(pass *analysis.Pass, format string, diag analysis.Diagnostic) {
+	rule, ok := messageRules[format]
+	if !ok {
+		panic(fmt.Sprintf("rsacheck: no rule for message %q", format))
+	}
+
+	diag.Category = rule.ID
+
+	cfg := configOf(pass)
+
+	if ruleDisabled(cfg, rule) || rule.Severity.rank() < cfg.MinSeverity.rank() || (!cfg.LintGenerated && inGeneratedFile(pass, diag.Pos)) ||
+		excluded(pass, cfg, diag.Pos) || allowedWeak(pass, diag.Pos) || nolinted(pass, diag.Pos) {
+		return
+	}
+
+	if cfg.ShowRuleIDs {
+		diag.Message = "[" + rule.ID + "] " + diag.Message
+	}
+
+	pass.Report(diag)
+}
@@ -0,0 +1,156 @@
+package rsacheck
+
+import (
+	"crypto"
+	"go/constant"
+	"go/types"
+	"maps"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// checkSignVerifyHashes checks if signatures created and verified with the same key
+// in the given function use the same hash algorithm, and if ciphertexts encrypted and
+// decrypted with OAEP and the same key do. A signature created with one hash will never
+// verify with another, nor will a ciphertext decrypt, which usually indicates a
+// copy-paste mistake.
+//
+// Only constant hash values, and hashes created by standard library functions, are
+// compared, and the key is matched by its SSA value, so both calls must happen in the
+// same function.
+func checkSignVerifyHashes(pass *analysis.Pass, fn *ssa.Function) {
+	signed := map[ssa.Value]int64{}
+	encrypted := map[ssa.Value]string{}
+
+	var verifies, decrypts []*ssa.Call
+
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			call, ok := instr.(*ssa.Call)
+			if !ok {
+				continue
+			}
+
+			switch checkedCallee(call) {
+			case signPKCS1v15, signPSS:
+				if hash, ok := call.Call.Args[2].(*ssa.Const); ok {
+					signed[call.Call.Args[1]] = hash.Int64()
+				}
+			case verifyPKCS1v15, verifyPSS:
+				verifies = append(verifies, call)
+			case encryptOAEP:
+				// The public key is expected to be &key.PublicKey, as when verifying.
+				if pub, ok := call.Call.Args[2].(*ssa.FieldAddr); ok {
+					if hash, ok := oaepHashName(call.Call.Args[0]); ok {
+						encrypted[pub.X] = hash
+					}
+				}
+			case decryptOAEP:
+				decrypts = append(decrypts, call)
+			}
+		}
+	}
+
+	for _, call := range verifies {
+		hash, ok := call.Call.Args[1].(*ssa.Const)
+		if !ok {
+			continue
+		}
+
+		// The public key is expected to be the address of the PublicKey field
+		// embedded in the private key used for signing (&key.PublicKey).
+		pub, ok := call.Call.Args[0].(*ssa.FieldAddr)
+		if !ok {
+			continue
+		}
+
+		signedHash, ok := signed[pub.X]
+		if ok && signedHash != hash.Int64() {
+			reportf(pass, call.Pos(), hashMismatchMessage, hashName(hash.Int64()), hashName(signedHash))
+		}
+	}
+
+	for _, call := range decrypts {
+		hash, ok := oaepHashName(call.Call.Args[0])
+		if !ok {
+			continue
+		}
+
+		encryptedHash, ok := encrypted[call.Call.Args[2]]
+		if ok && encryptedHash != hash {
+			reportf(pass, call.Pos(), oaepHashMismatchMessage, hash, encryptedHash)
+		}
+	}
+}
+
+// oaepHashName returns the name of the hash given to [crypto/rsa.EncryptOAEP] or
+// [crypto/rsa.DecryptOAEP], if it's created by a standard library function.
+func oaepHashName(hash ssa.Value) (string, bool) {
+	call, ok := callTo(hash, slices.Collect(maps.Keys(hashFunctions))...)
+	if !ok {
+		return "", false
+	}
+	return hashFunctions[calleeName(call)].String(), true
+}
+
+// hashName returns the name of the given crypto.Hash value, such as "SHA-256".
+func hashName(hash int64) string {
+	return crypto.Hash(hash).String()
+}
+
+// checkUnhashedSignature checks if [crypto/rsa.SignPKCS1v15] is called with crypto.Hash(0),
+// which signs the data directly, on a raw message instead of a digest. Signing a message
+// directly fails for messages longer than the key size, and is wrong regardless, since
+// the message isn't bound to a hash function.
+//
+// It reports whether the finding was reported.
+func checkUnhashedSignature(pass *analysis.Pass, instr *ssa.Call) bool {
+	if !zeroHash(instr.Call.Args[2]) || !rawMessage(instr.Call.Args[3]) {
+		return false
+	}
+
+	reportf(pass, instr.Pos(), unhashedSignatureMessage)
+	return true
+}
+
+// checkZeroHash checks if [crypto/rsa.SignPKCS1v15] or [crypto/rsa.VerifyPKCS1v15] is
+// called with crypto.Hash(0), which disables hashing, so the data is signed or verified
+// as is. Even when the data is a digest, the hash function it was computed with isn't
+// bound to the signature.
+func checkZeroHash(pass *analysis.Pass, instr *ssa.Call, hash ssa.Value) {
+	if zeroHash(hash) {
+		reportf(pass, instr.Pos(), zeroHashMessage)
+	}
+}
+
+// zeroHash reports whether the given value is the constant crypto.Hash(0).
+func zeroHash(hash ssa.Value) bool {
+	c, ok := hash.(*ssa.Const)
+	return ok && c.Value != nil && isType(c.Type(), "crypto", "Hash") && c.Int64() == 0
+}
+
+// rawMessage reports whether the given data is a message rather than a digest, because
+// it's converted from a string, or read from a file or stream. Constant strings with the
+// size of a digest are assumed to be digests.
+func rawMessage(data ssa.Value) bool {
+	if _, ok := callTo(data, osReadFile, ioReadAll); ok {
+		return true
+	}
+
+	conv, ok := data.(*ssa.Convert)
+	if !ok {
+		return false
+	}
+
+	if basic, ok := conv.X.Type().Underlying().(*types.Basic); !ok || basic.Info()&types.IsString == 0 {
+		return false
+	}
+
+	if c, ok := conv.X.(*ssa.Const); ok && c.Value != nil && c.Value.Kind() == constant.String {
+		return !digestSize(len(constant.StringVal(c.Value)))
+	}
+
+	return true
+}
@@ -0,0 +1,11 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+// GenerateKey generates a new RSA key.
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
@@ -0,0 +1,13 @@
+//go:build rsalint_allow_weak
+
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+// testKey generates a small key quickly, which is only used in tests.
+func testKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 512)
+}
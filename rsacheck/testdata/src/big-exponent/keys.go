@@ -0,0 +1,46 @@
+package keys
+
+import (
+	"crypto/rsa"
+	"math/big"
+)
+
+func PublicKeyFromString(n *big.Int) *rsa.PublicKey {
+	e := new(big.Int)
+	e.SetString("3", 10)
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())} // want "small exponents such as 3 are vulnerable"
+}
+
+func PublicKeyFromInt64(n *big.Int) *rsa.PublicKey {
+	e := new(big.Int).SetInt64(17)
+
+	pub := &rsa.PublicKey{N: n}
+	pub.E = int(e.Int64()) // want "small exponents such as 17 are vulnerable"
+	return pub
+}
+
+func PublicKeyFromVariable(n *big.Int) *rsa.PublicKey {
+	var e big.Int
+	e.SetUint64(5)
+
+	exponent := int(e.Uint64())
+
+	pub := &rsa.PublicKey{N: n}
+	pub.E = exponent // want "small exponents such as 5 are vulnerable"
+	return pub
+}
+
+func PublicKeyFromHex(n *big.Int) *rsa.PublicKey {
+	e, _ := new(big.Int).SetString("3", 16)
+	return &rsa.PublicKey{N: n, E: int(e.Int64())} // want "small exponents such as 3 are vulnerable"
+}
+
+func PublicKeyFromNewInt(n *big.Int) *rsa.PublicKey {
+	return &rsa.PublicKey{N: n, E: int(big.NewInt(65537).Int64())}
+}
+
+func PublicKey(n *big.Int) *rsa.PublicKey {
+	e, _ := new(big.Int).SetString("10001", 16)
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}
+}
@@ -0,0 +1,25 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+const legacyBits = 1024
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
+
+func GenerateMultiPrimeKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, 3, (1536)) // want "use 2048 bits or greater" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
+
+// Named constants and expressions may be used elsewhere, so they aren't rewritten.
+func GenerateLegacyKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, legacyBits) // want "use 2048 bits or greater"
+}
+
+func GenerateShiftedKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1<<10) // want "use 2048 bits or greater"
+}
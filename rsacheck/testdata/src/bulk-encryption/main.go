@@ -0,0 +1,47 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"io"
+	"os"
+)
+
+func main() {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	data, err := os.ReadFile("secrets.db")
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = rsa.EncryptOAEP(sha256.New(), rand.Reader, &privateKey.PublicKey, data, nil) // want "do not encrypt bulk data with RSA; use hybrid encryption and only encrypt a symmetric key with RSA"
+	if err != nil {
+		panic(err)
+	}
+
+	stream, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = rsa.EncryptPKCS1v15(rand.Reader, &privateKey.PublicKey, stream[:64]) // want "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15" "do not encrypt bulk data with RSA; use hybrid encryption and only encrypt a symmetric key with RSA"
+	if err != nil {
+		panic(err)
+	}
+
+	// Encrypting a small symmetric key is the intended use of RSA.
+	sessionKey := make([]byte, 32)
+	if _, err := rand.Read(sessionKey); err != nil {
+		panic(err)
+	}
+
+	_, err = rsa.EncryptOAEP(sha256.New(), rand.Reader, &privateKey.PublicKey, sessionKey, nil)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,48 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"log"
+)
+
+func IgnoredError() *rsa.PublicKey {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048) // want "error returned by rsa.GenerateKey is ignored; handle it, since the key is nil if generation fails"
+	return &key.PublicKey
+}
+
+func OverwrittenError() (*rsa.PrivateKey, *rsa.PrivateKey, error) {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048) // want "error returned by rsa.GenerateKey is ignored; handle it, since the key is nil if generation fails"
+	encryptionKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	return signingKey, encryptionKey, err
+}
+
+func IgnoredMultiPrimeError() *rsa.PrivateKey {
+	key, _ := rsa.GenerateMultiPrimeKey(rand.Reader, 2, 2048) // want "error returned by rsa.GenerateMultiPrimeKey is ignored; handle it, since the key is nil if generation fails" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+	return key
+}
+
+func HandledError() (*rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func ReturnedError() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 2048)
+}
+
+func LoggedError() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return key
+}
+
+func OnlyError() error {
+	_, err := rsa.GenerateKey(rand.Reader, 2048)
+	return err
+}
@@ -0,0 +1,25 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+)
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 3072) // want "use 4096 bits or greater"
+}
+
+func GenerateLargeKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 4096)
+}
+
+func EncryptFile(pub *rsa.PublicKey, name string) ([]byte, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// PKCS #1 v1.5 encryption isn't reported, since the group of checks is disabled.
+	return rsa.EncryptPKCS1v15(rand.Reader, pub, data) // want "do not encrypt bulk data with RSA"
+}
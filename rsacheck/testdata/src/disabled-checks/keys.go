@@ -0,0 +1,17 @@
+package keys
+
+import (
+	"crypto/rsa"
+	"math/rand"
+)
+
+func Encrypt(msg []byte) ([]byte, error) {
+	r := rand.New(rand.NewSource(1))
+
+	key, err := rsa.GenerateMultiPrimeKey(r, 3, 1024) // want "use 2048 bits or greater" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+	if err != nil {
+		return nil, err
+	}
+
+	return rsa.EncryptPKCS1v15(r, &key.PublicKey, msg)
+}
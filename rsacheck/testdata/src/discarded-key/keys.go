@@ -0,0 +1,58 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048) // want "RSA key is generated but overwritten before it's used"
+	if err != nil {
+		return nil, err
+	}
+
+	key, err = rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func GenerateKeyOnError() (*rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		key, err = rsa.GenerateKey(rand.Reader, 3072)
+	}
+
+	return key, err
+}
+
+func GenerateUnusedKey() error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048) // want "RSA key is generated but never used"
+	_ = key
+	return err
+}
+
+func CheckKeyGeneration() error {
+	_, err := rsa.GenerateKey(rand.Reader, 2048)
+	return err
+}
+
+func GenerateKeys() ([]*rsa.PrivateKey, error) {
+	var keys []*rsa.PrivateKey
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	keys = append(keys, key)
+
+	key, err = rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	keys = append(keys, key)
+
+	return keys, nil
+}
@@ -0,0 +1,38 @@
+package sign
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+)
+
+func Sign(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+
+	sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil) // want "signature returned by rsa.SignPSS is never used"
+	if err != nil {
+		return nil, err
+	}
+	_ = sig
+
+	return digest[:], nil
+}
+
+func CheckSign(key *rsa.PrivateKey, msg []byte) error {
+	digest := sha256.Sum256(msg)
+
+	_, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:]) // want "signature returned by rsa.SignPKCS1v15 is never used" "prefer rsa.SignPSS"
+	return err
+}
+
+func SignMessage(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+
+	sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig, nil
+}
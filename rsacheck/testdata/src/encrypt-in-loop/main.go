@@ -0,0 +1,57 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"os"
+)
+
+func encryptAll(pub *rsa.PublicKey, msgs [][]byte) [][]byte {
+	var out [][]byte
+	for _, msg := range msgs {
+		ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, msg, nil) // want "rsa.EncryptOAEP is called in a loop over an unbounded number of messages; limit the input or use hybrid encryption"
+		if err != nil {
+			panic(err)
+		}
+		out = append(out, ciphertext)
+	}
+	return out
+}
+
+func encryptStream(pub *rsa.PublicKey, msgs <-chan []byte) {
+	for msg := range msgs {
+		ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, msg, nil) // want "rsa.EncryptOAEP is called in a loop over an unbounded number of messages; limit the input or use hybrid encryption"
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(ciphertext)
+	}
+}
+
+func encryptFew(pub *rsa.PublicKey, msg []byte) {
+	for i := 0; i < 3; i++ {
+		ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, msg, nil)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(ciphertext)
+	}
+}
+
+func main() {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	msgs := make([][]byte, len(os.Args))
+	for i, arg := range os.Args {
+		msgs[i] = []byte(arg)
+	}
+
+	fmt.Println(encryptAll(&privateKey.PublicKey, msgs))
+
+	encryptFew(&privateKey.PublicKey, msgs[0])
+}
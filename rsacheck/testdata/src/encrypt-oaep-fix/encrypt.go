@@ -0,0 +1,18 @@
+package encrypt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func Encrypt(pub *rsa.PublicKey, msg []byte) ([]byte, error) {
+	return rsa.EncryptPKCS1v15(rand.Reader, pub, msg) // want "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15"
+}
+
+func EncryptKey(pub *rsa.PublicKey, key []byte) ([]byte, error) {
+	return rsa.EncryptPKCS1v15( // want "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15"
+		rand.Reader,
+		pub,
+		key[:32],
+	)
+}
@@ -0,0 +1,12 @@
+package encrypt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	sha "crypto/sha256"
+)
+
+func EncryptDigest(pub *rsa.PublicKey, msg []byte) ([]byte, error) {
+	digest := sha.Sum256(msg)
+	return rsa.EncryptPKCS1v15(rand.Reader, pub, digest[:]) // want "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15"
+}
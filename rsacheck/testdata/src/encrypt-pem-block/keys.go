@@ -0,0 +1,26 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+)
+
+func encryptKey(key *rsa.PrivateKey, password []byte) (*pem.Block, error) {
+	return x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key), password, x509.PEMCipherAES256) // want "x509.EncryptPEMBlock is insecure and deprecated; use a modern KDF/AEAD"
+}
+
+func main() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	block, err := encryptKey(key, []byte(os.Getenv("KEY_PASSWORD")))
+	if err != nil {
+		panic(err)
+	}
+	_ = pem.Encode(os.Stdout, block)
+}
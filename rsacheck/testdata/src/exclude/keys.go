@@ -0,0 +1,10 @@
+package exclude
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
@@ -0,0 +1,10 @@
+package exclude
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func GenerateLegacyKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024)
+}
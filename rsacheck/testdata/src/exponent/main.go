@@ -0,0 +1,28 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"math/big"
+)
+
+func main() {
+	brokenKey, err := rsa.GenerateKey(rand.Reader, 1024) // want "RSA key with 1024 bits and public exponent 3 is broken; use 2048 bits or greater and an exponent of 65537"
+	if err != nil {
+		panic(err)
+	}
+	brokenKey.E = 3
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	privateKey.PublicKey.E = 3 // want "use a public exponent of 65537; small exponents such as 3 are vulnerable to several attacks"
+
+	publicKey := &rsa.PublicKey{N: privateKey.N, E: 17} // want "use a public exponent of 65537; small exponents such as 17 are vulnerable to several attacks"
+
+	goodKey := &rsa.PublicKey{N: new(big.Int).Set(privateKey.N), E: 65537}
+
+	fmt.Println(brokenKey, publicKey, goodKey)
+}
@@ -0,0 +1,53 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+)
+
+var (
+	legacyMode    = true
+	strongKeys    = false
+	compatibility = false
+)
+
+func init() {
+	if os.Getenv("MODERN") != "" {
+		legacyMode = false
+	}
+}
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	var bits int
+	if !legacyMode {
+		bits = 2048
+	} else {
+		bits = 1024
+	}
+	return rsa.GenerateKey(rand.Reader, bits) // want "number of bits is 1024 on the path taken by default, since feature flag legacyMode defaults to true"
+}
+
+func GenerateStrongKey() (*rsa.PrivateKey, error) {
+	bits := 1024
+	if !strongKeys {
+		bits = 2048
+	}
+	return rsa.GenerateKey(rand.Reader, bits) // want "number of bits may be 1024 depending on the path taken"
+}
+
+func GenerateWeakKey() (*rsa.PrivateKey, error) {
+	bits := 4096
+	if !strongKeys {
+		bits = 1024
+	}
+	return rsa.GenerateKey(rand.Reader, bits) // want "number of bits is 1024 on the path taken by default, since feature flag strongKeys defaults to false"
+}
+
+func GenerateCompatibleKey() (*rsa.PrivateKey, error) {
+	bits := 2048
+	if compatibility {
+		bits = 1024
+	}
+	return rsa.GenerateKey(rand.Reader, bits) // want "number of bits may be 1024 depending on the path taken"
+}
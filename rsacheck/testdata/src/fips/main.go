@@ -0,0 +1,33 @@
+//go:build boringcrypto
+
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+)
+
+func main() {
+	multiPrimeKey, err := rsa.GenerateMultiPrimeKey(rand.Reader, 3, 2048) // want "a 3-prime RSA key is not approved in FIPS 140 mode, and will fail at runtime" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+	if err != nil {
+		panic(err)
+	}
+
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater" "a 1024-bit RSA key is not approved in FIPS 140 mode, and will fail at runtime"
+	if err != nil {
+		panic(err)
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	ciphertext, err := rsa.EncryptPKCS1v15(rand.Reader, &privateKey.PublicKey, []byte("secret")) // want "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15" "rsa.EncryptPKCS1v15 is not approved in FIPS 140 mode, and will fail at runtime"
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(multiPrimeKey, smallKey, ciphertext)
+}
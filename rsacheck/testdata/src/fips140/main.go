@@ -0,0 +1,18 @@
+//go:debug fips140=on
+
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+)
+
+func main() {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2047) // want "use 2048 bits or greater" "use a multiple of 8 bits for RSA keys" "a 2047-bit RSA key is not approved in FIPS 140 mode, and will fail at runtime"
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(privateKey)
+}
@@ -0,0 +1,44 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+)
+
+const base = 512
+
+func GenerateLiteralKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1<<10) // want "use 2048 bits or greater"
+}
+
+func GenerateMultipliedKey() (*rsa.PrivateKey, error) {
+	factor := 2
+	return rsa.GenerateKey(rand.Reader, base*factor) // want "use 2048 bits or greater"
+}
+
+func GenerateShiftedKey() (*rsa.PrivateKey, error) {
+	one := 1
+	return rsa.GenerateKey(rand.Reader, one<<10) // want "use 2048 bits or greater"
+}
+
+func GenerateAddedKey() (*rsa.PrivateKey, error) {
+	bits := base
+	bits += 512
+	return rsa.GenerateKey(rand.Reader, bits) // want "use 2048 bits or greater"
+}
+
+func GenerateNestedKey() (*rsa.PrivateKey, error) {
+	factor := 2
+	bits := (base*factor - 512) << 1
+	return rsa.GenerateKey(rand.Reader, bits) // want "use 2048 bits or greater"
+}
+
+func GenerateStrongKey() (*rsa.PrivateKey, error) {
+	factor := 6
+	return rsa.GenerateKey(rand.Reader, base*factor)
+}
+
+func GenerateDynamicKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, base*len(os.Args))
+}
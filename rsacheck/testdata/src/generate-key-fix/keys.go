@@ -0,0 +1,27 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, 2, 2048) // want "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
+
+func GenerateLargeKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey( // want "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+		rand.Reader,
+		2,
+		4096,
+	)
+}
+
+// Keys with more primes aren't equivalent, so the call isn't rewritten.
+func GenerateThreePrimeKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, 3, 2048) // want "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
+
+func GenerateVariablePrimeKey(nprimes int) (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, nprimes, 2048) // want "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
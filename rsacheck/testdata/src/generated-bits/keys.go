@@ -0,0 +1,32 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+
+	"generated-bits/config"
+)
+
+//go:generate keygen -bits 4096 -o keybits_gen.go
+
+const handwrittenBits = 4096
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, KeyBits) // want "number of bits is set by KeyBits, which is declared in generated file keybits_gen.go; key sizes in generated code are harder to audit"
+}
+
+func GenerateConfiguredKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, config.KeyBits) // want "number of bits is set by KeyBits, which is declared in generated file config_gen.go; key sizes in generated code are harder to audit"
+}
+
+func GenerateWeakConfiguredKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, config.LegacyKeyBits) // want "number of bits is set by LegacyKeyBits, which is declared in generated file config_gen.go; key sizes in generated code are harder to audit" "use 2048 bits or greater"
+}
+
+func GenerateHandwrittenKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, handwrittenBits)
+}
+
+func GenerateLiteralKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 4096)
+}
@@ -0,0 +1,45 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/gob"
+	"io"
+)
+
+// Session is cached between requests.
+type Session struct {
+	ID  string
+	Key *rsa.PrivateKey
+}
+
+// session is cached between requests, but its key is never encoded.
+type session struct {
+	ID  string
+	key *rsa.PrivateKey
+}
+
+func SaveKey(w io.Writer) error {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+
+	return gob.NewEncoder(w).Encode(key) // want "\\*crypto/rsa.PrivateKey contains an RSA private key"
+}
+
+func SaveSession(w io.Writer, s *Session) error {
+	return gob.NewEncoder(w).Encode(s) // want "\\*gob-private-key.Session contains an RSA private key"
+}
+
+func SaveSessions(w io.Writer, s map[string]Session) error {
+	return gob.NewEncoder(w).Encode(s) // want "map\\[string\\]gob-private-key.Session contains an RSA private key"
+}
+
+func SaveUnexported(w io.Writer, s *session) error {
+	return gob.NewEncoder(w).Encode(s)
+}
+
+func SavePublicKey(w io.Writer, key *rsa.PrivateKey) error {
+	return gob.NewEncoder(w).Encode(&key.PublicKey)
+}
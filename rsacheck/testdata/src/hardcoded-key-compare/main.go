@@ -0,0 +1,32 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"math/big"
+)
+
+func isTestKey(key *rsa.PrivateKey) bool {
+	modulus, _ := new(big.Int).SetString("c2a7e3b1f0d9", 16)
+
+	return key.N.Cmp(modulus) == 0 // want "RSA key modulus is compared to a hardcoded value"
+}
+
+func isBackdoor(key *rsa.PrivateKey) bool {
+	return big.NewInt(65537).Cmp(key.D) == 0 // want "RSA key private exponent is compared to a hardcoded value"
+}
+
+func isKnownKey(pub *rsa.PublicKey) bool {
+	return pub.N.String() == "13407807929942597099574024998205846127479365820592393377723561443721764030073546976801874298166903427690031858186486050853753882811946569946433649006084171" // want "RSA key modulus is compared to a hardcoded value"
+}
+
+func sameKey(a, b *rsa.PublicKey) bool {
+	return a.N.Cmp(b.N) == 0 && a.E == b.E
+}
+
+func main() {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	fmt.Println(isTestKey(key), isBackdoor(key), isKnownKey(&key.PublicKey), sameKey(&key.PublicKey, &key.PublicKey))
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+)
+
+func main() {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	msg := []byte("Thu Dec 19 18:06:16 EST 2013\n")
+
+	hashed := sha256.Sum256(msg)
+
+	sig, err := rsa.SignPSS(rand.Reader, privateKey, crypto.SHA256, hashed[:], nil)
+	if err != nil {
+		panic(err)
+	}
+
+	hashed384 := sha512.Sum384(msg)
+
+	if err := rsa.VerifyPSS(&privateKey.PublicKey, crypto.SHA384, hashed384[:], sig, nil); err != nil { // want "signature is verified using SHA-384, but was signed using SHA-256 with the same key"
+		panic(err)
+	}
+
+	if err := rsa.VerifyPSS(&privateKey.PublicKey, crypto.SHA256, hashed[:], sig, nil); err != nil {
+		panic(err)
+	}
+
+	decryptionKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &decryptionKey.PublicKey, msg, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err := rsa.DecryptOAEP(sha512.New(), nil, decryptionKey, ciphertext, nil); err != nil { // want "ciphertext is decrypted using SHA-512, but was encrypted using SHA-256 with the same key"
+		panic(err)
+	}
+
+	if _, err := rsa.DecryptOAEP(sha256.New(), nil, decryptionKey, ciphertext, nil); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+)
+
+func main() {
+	rsa.GenerateKey(rand.Reader, sha256.Size)                   // want "sha256.Size is the size of a hash in bytes, not the number of bits of an RSA key"
+	rsa.GenerateKey(rand.Reader, (sha512.Size))                 // want "sha512.Size is the size of a hash in bytes, not the number of bits of an RSA key"
+	rsa.GenerateKey(rand.Reader, crypto.SHA256.Size())          // want "crypto.Hash.Size\\(\\) is the size of a hash in bytes, not the number of bits of an RSA key"
+	rsa.GenerateMultiPrimeKey(rand.Reader, 2, newHash().Size()) // want "hash.Hash.Size\\(\\) is the size of a hash in bytes, not the number of bits of an RSA key" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+
+	// Converting the size to bits is still weak, but isn't a confusion of units.
+	rsa.GenerateKey(rand.Reader, sha512.Size*8) // want "use 2048 bits or greater"
+}
+
+func newHash() hash.Hash {
+	return sha256.New()
+}
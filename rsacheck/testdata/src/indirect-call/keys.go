@@ -0,0 +1,55 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+)
+
+var (
+	generate   = rsa.GenerateKey
+	reassigned = rsa.GenerateKey
+)
+
+func SetGenerator(gen generator) {
+	reassigned = gen
+}
+
+type generator func(io.Reader, int) (*rsa.PrivateKey, error)
+
+func GenerateLocal() (*rsa.PrivateKey, error) {
+	gen := rsa.GenerateKey
+	return gen(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
+
+func GenerateGlobal() (*rsa.PrivateKey, error) {
+	return generate(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
+
+// Calls of function values that can't be resolved statically aren't checked, but
+// must not cause a panic.
+func GenerateReassigned() (*rsa.PrivateKey, error) {
+	return reassigned(rand.Reader, 1024)
+}
+
+func GenerateParam(gen generator) (*rsa.PrivateKey, error) {
+	return gen(rand.Reader, 1024)
+}
+
+func GenerateConverted() (*rsa.PrivateKey, error) {
+	gen := generator(rsa.GenerateKey)
+	return gen(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
+
+func GenerateMultiPrime() (*rsa.PrivateKey, error) {
+	gen := rsa.GenerateMultiPrimeKey
+	return gen(rand.Reader, 2, 1024) // want "use 2048 bits or greater" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
+
+func GenerateDeferred() {
+	defer rsa.GenerateKey(rand.Reader, 1024)
+}
+
+func GenerateGoroutine() {
+	go rsa.GenerateKey(rand.Reader, 1024)
+}
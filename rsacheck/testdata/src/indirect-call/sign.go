@@ -0,0 +1,30 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+)
+
+var (
+	signPSS   = rsa.SignPSS
+	verifyPSS = rsa.VerifyPSS
+)
+
+func SignDiscarded(key *rsa.PrivateKey, digest []byte) error {
+	sign := rsa.SignPSS
+	_, err := sign(rand.Reader, key, crypto.SHA256, digest, nil) // want "signature returned by rsa.SignPSS is never used"
+	return err
+}
+
+func SignVerifyMismatch(key *rsa.PrivateKey, msg []byte) error {
+	digest := sha256.Sum256(msg)
+
+	sig, err := signPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+	if err != nil {
+		return err
+	}
+
+	return verifyPSS(&key.PublicKey, crypto.SHA384, digest[:], sig, nil) // want "signature is verified using SHA-384, but was signed using SHA-256 with the same key"
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+)
+
+func NewClient(cert []byte) (*http.Client, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{cert},
+			PrivateKey:  key,
+		}},
+		InsecureSkipVerify: true, // want "InsecureSkipVerify is set in a function that uses an RSA key"
+	}
+
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: config}}, nil
+}
+
+func NewClientWithKey(key *rsa.PrivateKey, cert []byte) *tls.Config {
+	config := &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{cert}, PrivateKey: key}},
+	}
+	config.InsecureSkipVerify = true // want "InsecureSkipVerify is set in a function that uses an RSA key"
+	return config
+}
+
+func NewParsedClient(der, cert []byte) (*tls.Config, error) {
+	key, err := x509.ParsePKCS1PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates:       []tls.Certificate{{Certificate: [][]byte{cert}, PrivateKey: key}},
+		InsecureSkipVerify: false,
+	}, nil
+}
+
+func NewInsecureClient() *tls.Config {
+	return &tls.Config{InsecureSkipVerify: true}
+}
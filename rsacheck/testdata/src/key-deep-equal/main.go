@@ -0,0 +1,32 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"reflect"
+)
+
+func main() {
+	a, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	b, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	if reflect.DeepEqual(a, b) { // want "do not compare RSA private keys with reflect.DeepEqual; use the constant-time PrivateKey.Equal method"
+		fmt.Println("same key")
+	}
+
+	if reflect.DeepEqual(a.PublicKey, b.PublicKey) {
+		fmt.Println("same public key")
+	}
+
+	if a.Equal(b) {
+		fmt.Println("same key")
+	}
+}
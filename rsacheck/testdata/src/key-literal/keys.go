@@ -0,0 +1,54 @@
+package keys
+
+import (
+	"crypto/rsa"
+	"errors"
+	"math/big"
+)
+
+const modulus = "c5e9f8a4b2d1e3f7a9c8b6d4e2f1a3b5c7d9e8f6a4b2c1d3e5f7a9b8c6d4e2f1"
+
+func SmallPublicKey() *rsa.PublicKey {
+	return &rsa.PublicKey{N: big.NewInt(3233), E: 65537} // want "RSA key literal has a 12-bit modulus; use 2048 bits or greater"
+}
+
+func HexPublicKey() *rsa.PublicKey {
+	n, _ := new(big.Int).SetString(modulus, 16)
+	return &rsa.PublicKey{N: n, E: 65537} // want "RSA key literal has a 256-bit modulus; use 2048 bits or greater"
+}
+
+func BytesPublicKey() *rsa.PublicKey {
+	return &rsa.PublicKey{N: new(big.Int).SetBytes([]byte("0123456789abcdef")), E: 65537} // want "RSA key literal has a 126-bit modulus; use 2048 bits or greater"
+}
+
+func SmallPrivateKey(d *big.Int, primes []*big.Int) *rsa.PrivateKey {
+	return &rsa.PrivateKey{ // want "RSA key literal has a 12-bit modulus; use 2048 bits or greater"
+		PublicKey: rsa.PublicKey{N: big.NewInt(3233), E: 65537},
+		D:         d,
+		Primes:    primes,
+	}
+}
+
+func LoadPublicKey(n *big.Int, e int) *rsa.PublicKey {
+	return &rsa.PublicKey{N: n, E: e} // want "RSA key is constructed from a literal without validating its size; check that its modulus has at least 2048 bits when it's loaded"
+}
+
+func LoadValidatedPublicKey(n *big.Int, e int) (*rsa.PublicKey, error) {
+	key := &rsa.PublicKey{N: n, E: e}
+	if key.N.BitLen() < 2048 {
+		return nil, errors.New("key is too small")
+	}
+	return key, nil
+}
+
+func LoadSizedPublicKey(n *big.Int, e int) (*rsa.PublicKey, error) {
+	key := &rsa.PublicKey{N: n, E: e}
+	if key.Size() < 256 {
+		return nil, errors.New("key is too small")
+	}
+	return key, nil
+}
+
+func EmptyPublicKey() *rsa.PublicKey {
+	return &rsa.PublicKey{}
+}
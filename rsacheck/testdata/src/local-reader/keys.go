@@ -0,0 +1,59 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	mathrand "math/rand"
+)
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	reader := rand.Reader
+	return rsa.GenerateKey(reader, 2048)
+}
+
+func GenerateCapturedKey() (*rsa.PrivateKey, error) {
+	var reader io.Reader = rand.Reader
+
+	generate := func() (*rsa.PrivateKey, error) {
+		return rsa.GenerateKey(reader, 2048)
+	}
+
+	return generate()
+}
+
+func GenerateAddressedKey() (*rsa.PrivateKey, error) {
+	reader := rand.Reader
+	readers := []*io.Reader{&reader}
+	_ = readers
+
+	return rsa.GenerateKey(reader, 2048)
+}
+
+func GenerateWeakKey() (*rsa.PrivateKey, error) {
+	reader := mathrand.New(mathrand.NewSource(1))
+	return rsa.GenerateKey(reader, 2048) // want "math/rand is not cryptographically secure" "predictable seed makes key generation deterministic"
+}
+
+func GenerateCapturedWeakKey() (*rsa.PrivateKey, error) {
+	reader := mathrand.New(mathrand.NewSource(1))
+
+	defer func() {
+		_ = reader.Int()
+	}()
+
+	return rsa.GenerateKey(reader, 2048) // want "math/rand is not cryptographically secure" "predictable seed makes key generation deterministic"
+}
+
+func GenerateReassignedKey(weak bool) (*rsa.PrivateKey, error) {
+	var reader io.Reader = rand.Reader
+
+	set := func() {
+		if weak {
+			reader = mathrand.New(mathrand.NewSource(1))
+		}
+	}
+	set()
+
+	return rsa.GenerateKey(reader, 2048)
+}
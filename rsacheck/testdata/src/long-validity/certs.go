@@ -0,0 +1,80 @@
+package certs
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"time"
+)
+
+func CreateRootCertificate() ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Root CA"},
+		NotBefore:    now,
+		NotAfter:     now.AddDate(50, 0, 0),
+		IsCA:         true,
+	}
+
+	return x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key) // want "RSA key with 2048 bits is used for a certificate valid for 50 years"
+}
+
+func CreateDurationCertificate() ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    now,
+		NotAfter:     now.Add(30 * 365 * 24 * time.Hour),
+	}
+
+	return x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key) // want "RSA key with 2048 bits is used for a certificate valid for 30 years"
+}
+
+func CreateShortLivedCertificate() ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    now,
+		NotAfter:     now.AddDate(1, 0, 0),
+	}
+
+	return x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+}
+
+func CreateLongLivedCertificate() ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    now,
+		NotAfter:     now.AddDate(50, 0, 0),
+	}
+
+	return x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+}
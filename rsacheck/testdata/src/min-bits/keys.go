@@ -0,0 +1,26 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 2048) // want "use 4096 bits or greater"
+}
+
+func GenerateLegacyKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 3072) // want "use 4096 bits or greater"
+}
+
+func GenerateStrongKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 4096)
+}
+
+func GenerateKeyForPath(legacy bool) (*rsa.PrivateKey, error) {
+	bits := 4096
+	if legacy {
+		bits = 3072
+	}
+	return rsa.GenerateKey(rand.Reader, bits) // want "number of bits may be 3072 depending on the path taken; use 4096 bits or greater"
+}
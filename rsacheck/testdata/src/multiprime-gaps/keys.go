@@ -0,0 +1,33 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func GenerateFourPrimes() (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, 4, 3072) // want "for 3072 bits 3 is the max number of primes to use" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
+
+func GenerateThreePrimes() (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, 3, 3072) // want "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
+
+func GenerateLargeKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, 5, 6144) // want "for 6144 bits 4 is the max number of primes to use" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
+
+func GenerateHugeKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, 6, 16384) // want "for 16384 bits 5 is the max number of primes to use" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
+
+var keyBits = 3072
+
+func GenerateGlobalKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, 4, keyBits) // want "for 3072 bits 3 is the max number of primes to use" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
+
+func GenerateMultipliedKey() (*rsa.PrivateKey, error) {
+	factor := 3
+	return rsa.GenerateMultiPrimeKey(rand.Reader, 4, factor*1024) // want "for 3072 bits 3 is the max number of primes to use" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
@@ -0,0 +1,28 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+)
+
+// randReader can be replaced to generate keys deterministically.
+var randReader io.Reader = rand.Reader
+
+// reader is never reassigned.
+var reader io.Reader = rand.Reader
+
+// SetReader sets the random reader used to generate keys.
+func SetReader(r io.Reader) {
+	randReader = r
+}
+
+// GenerateKey generates a new RSA key.
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(randReader, 2048) // want "random reader randReader is a package variable that is reassigned"
+}
+
+// GenerateOtherKey generates a new RSA key.
+func GenerateOtherKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(reader, 2048)
+}
@@ -0,0 +1,14 @@
+package keys
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGenerateKey(t *testing.T) {
+	SetReader(rand.New(rand.NewSource(1)))
+
+	if _, err := GenerateKey(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,39 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"io"
+)
+
+// GenerateKey generates a new RSA key using the given random reader, which defaults
+// to the crypto/rand.Reader if nil.
+func GenerateKey(r io.Reader) (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(r, 2048) // want "random reader parameter r is passed to rsa.GenerateKey without handling nil"
+}
+
+// GenerateKeyWithFallback generates a new RSA key using the given random reader, which
+// defaults to the crypto/rand.Reader if nil.
+func GenerateKeyWithFallback(r io.Reader) (*rsa.PrivateKey, error) {
+	if r == nil {
+		r = rand.Reader
+	}
+	return rsa.GenerateKey(r, 2048)
+}
+
+// GenerateKeyWithReader generates a new RSA key using the given random reader, which
+// is required.
+func GenerateKeyWithReader(r io.Reader) (*rsa.PrivateKey, error) {
+	if r == nil {
+		return nil, errors.New("keys: nil random reader")
+	}
+	return rsa.GenerateKey(r, 2048)
+}
+
+// GenerateKeyIndirect generates a new RSA key using the given random reader, through a
+// function value.
+func GenerateKeyIndirect(r io.Reader) (*rsa.PrivateKey, error) {
+	generate := rsa.GenerateKey
+	return generate(r, 2048) // want "random reader parameter r is passed to rsa.GenerateKey without handling nil"
+}
@@ -0,0 +1,37 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	mathrand "math/rand"
+)
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
+
+func GenerateTestKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) //nolint:rsalint
+}
+
+func GenerateFixtureKey() (*rsa.PrivateKey, error) {
+	r := mathrand.New(mathrand.NewSource(1))
+	return rsa.GenerateKey(r, 1024) //nolint:gosec,rsalint // deterministic fixture key
+}
+
+func GenerateLegacyKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) //nolint
+}
+
+func GenerateAllKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) //nolint:all
+}
+
+func GenerateOtherKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) //nolint:gosec // want "use 2048 bits or greater"
+}
+
+func GenerateNextKey() (*rsa.PrivateKey, error) {
+	//nolint:rsalint
+	return rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"hash/crc32"
+	"hash/fnv"
+)
+
+func signFNV(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	h := fnv.New64a()
+	h.Write(msg)
+
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h.Sum(nil)) // want "signed digest is computed using hash/fnv, which is not a cryptographic hash" "prefer rsa.SignPSS over rsa.SignPKCS1v15 for new code"
+}
+
+func signCRC32(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	h := crc32.NewIEEE()
+	h.Write(msg)
+
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, h.Sum(nil), nil) // want "signed digest is computed using hash/crc32, which is not a cryptographic hash"
+}
+
+func signSHA256(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+}
+
+func signSHA256Hash(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	h := sha256.New()
+	h.Write(msg)
+
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h.Sum(nil)) // want "prefer rsa.SignPSS over rsa.SignPKCS1v15 for new code"
+}
+
+func main() {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	signFNV(key, []byte("hello"))
+	signCRC32(key, []byte("hello"))
+	signSHA256(key, []byte("hello"))
+	signSHA256Hash(key, []byte("hello"))
+}
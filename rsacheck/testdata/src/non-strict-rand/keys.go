@@ -0,0 +1,22 @@
+package keys
+
+import (
+	"crypto/rsa"
+	"io"
+	"math/rand"
+)
+
+// entropy returns a reader that can't be resolved by the analyzer.
+func entropy() io.Reader {
+	return source
+}
+
+var source io.Reader
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(entropy(), 2048)
+}
+
+func GenerateWeakKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.New(rand.NewSource(1)), 2048) // want "math/rand is not cryptographically secure; use crypto/rand.Reader" "predictable seed makes key generation deterministic"
+}
@@ -13,6 +13,11 @@ func main() {
 		panic(err)
 	}
 
+	decryptionKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
 	msg := []byte("Thu Dec 19 18:06:16 EST 2013\n")
 
 	hashed := sha256.Sum256(msg)
@@ -24,4 +29,13 @@ func main() {
 	if err := rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.SHA256, msg, sig); err != nil {
 		panic(err)
 	}
+
+	ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &decryptionKey.PublicKey, msg, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err := rsa.DecryptOAEP(sha256.New(), nil, decryptionKey, ciphertext, nil); err != nil {
+		panic(err)
+	}
 }
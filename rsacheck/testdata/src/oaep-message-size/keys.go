@@ -0,0 +1,48 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+)
+
+func EncryptSecret() ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+	if err != nil {
+		return nil, err
+	}
+
+	var secret [64]byte
+	if _, err := rand.Read(secret[:]); err != nil {
+		return nil, err
+	}
+
+	// A 1024-bit key with SHA-512 can encrypt at most 128 - 2*64 - 2 bytes.
+	return rsa.EncryptOAEP(sha512.New(), rand.Reader, &key.PublicKey, secret[:], nil) // want "message of 64 bytes is too long for OAEP with a 1024-bit key and SHA-512, which can encrypt at most 0 bytes"
+}
+
+func EncryptMessage() ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := []byte("a message that is longer than one hundred and ninety bytes, which is the most that OAEP with a 2048-bit key and SHA-256 can encrypt, since the padding takes up sixty six bytes of the two hundred and fifty six")
+
+	return rsa.EncryptOAEP(sha256.New(), rand.Reader, &key.PublicKey, msg, nil) // want "message of 208 bytes is too long for OAEP with a 2048-bit key and SHA-256, which can encrypt at most 190 bytes"
+}
+
+func EncryptKey() ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	var aesKey [32]byte
+	if _, err := rand.Read(aesKey[:]); err != nil {
+		return nil, err
+	}
+
+	return rsa.EncryptOAEP(sha256.New(), rand.Reader, &key.PublicKey, aesKey[:], nil)
+}
@@ -0,0 +1,27 @@
+package decrypt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+)
+
+func Decrypt(key *rsa.PrivateKey, ciphertext []byte) ([]byte, error) {
+	return rsa.DecryptPKCS1v15(rand.Reader, key, ciphertext) // want "PKCS#1 v1.5 decryption is vulnerable to padding oracles; prefer rsa.DecryptOAEP"
+}
+
+func DecryptSessionKey(key *rsa.PrivateKey, ciphertext []byte) ([]byte, error) {
+	sessionKey := make([]byte, 32)
+	if _, err := rand.Read(sessionKey); err != nil {
+		return nil, err
+	}
+
+	if err := rsa.DecryptPKCS1v15SessionKey(rand.Reader, key, ciphertext, sessionKey); err != nil {
+		return nil, err
+	}
+	return sessionKey, nil
+}
+
+func DecryptOAEP(key *rsa.PrivateKey, ciphertext []byte) ([]byte, error) {
+	return rsa.DecryptOAEP(sha256.New(), rand.Reader, key, ciphertext, nil)
+}
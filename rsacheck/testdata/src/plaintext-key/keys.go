@@ -0,0 +1,76 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"os"
+)
+
+func writePKCS1(key *rsa.PrivateKey) error {
+	der := x509.MarshalPKCS1PrivateKey(key) // want "private key is being serialized unencrypted by x509.MarshalPKCS1PrivateKey and written out; encrypt it before it's stored"
+	return os.WriteFile("key.der", der, 0o600)
+}
+
+func writePEM(w io.Writer, key *rsa.PrivateKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key) // want "private key is being serialized unencrypted by x509.MarshalPKCS8PrivateKey and written out; encrypt it before it's stored"
+	if err != nil {
+		return err
+	}
+	return pem.Encode(w, &pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func writeFile(f *os.File, key *rsa.PrivateKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key) // want "private key is being serialized unencrypted by x509.MarshalPKCS8PrivateKey and written out; encrypt it before it's stored"
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	return err
+}
+
+func writeEncrypted(aesKey []byte, key *rsa.PrivateKey) error {
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile("key.enc", aead.Seal(nonce, nonce, der, nil), 0o600)
+}
+
+func writePublic(key *rsa.PrivateKey) error {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile("key.pub", der, 0o644)
+}
+
+func main() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	_ = writePKCS1(key)
+	_ = writePEM(os.Stdout, key)
+	_ = writeFile(os.Stdout, key)
+	_ = writeEncrypted(make([]byte, 32), key)
+	_ = writePublic(key)
+}
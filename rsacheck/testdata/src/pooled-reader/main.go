@@ -0,0 +1,27 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"io"
+	"sync"
+)
+
+var readers = sync.Pool{
+	New: func() any {
+		return rand.Reader
+	},
+}
+
+func main() {
+	r := readers.Get().(io.Reader)
+	defer readers.Put(r)
+
+	privateKey, err := rsa.GenerateKey(r, 2048) // want "verify the random reader obtained from a sync.Pool is a cryptographically secure random number generator"
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(privateKey)
+}
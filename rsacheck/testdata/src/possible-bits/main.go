@@ -0,0 +1,35 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+)
+
+func generate(level string) (*rsa.PrivateKey, error) {
+	var bits int
+	switch level {
+	case "low":
+		bits = 1024
+	case "medium":
+		bits = 2048
+	case "high":
+		bits = 4096
+	}
+
+	return rsa.GenerateKey(rand.Reader, bits) // want "number of bits may be 1024 depending on the path taken"
+}
+
+func generateStrong(large bool) (*rsa.PrivateKey, error) {
+	bits := 3072
+	if large {
+		bits = 4096
+	}
+
+	return rsa.GenerateKey(rand.Reader, bits)
+}
+
+func main() {
+	generate(os.Args[1])
+	generateStrong(len(os.Args) > 2)
+}
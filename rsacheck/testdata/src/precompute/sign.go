@@ -0,0 +1,84 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"os"
+)
+
+func signAll(msgs [][]byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, msg := range msgs {
+		digest := sha256.Sum256(msg)
+		sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil) // want "RSA private key is used by rsa.SignPSS in a loop without calling Precompute; call key.Precompute\\(\\) once before the loop"
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(sig)
+	}
+}
+
+func decryptAll(ciphertexts [][]byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	for i := 0; i < len(ciphertexts); i++ {
+		msg, err := rsa.DecryptOAEP(sha256.New(), nil, key, ciphertexts[i], nil) // want "RSA private key is used by rsa.DecryptOAEP in a loop without calling Precompute; call key.Precompute\\(\\) once before the loop"
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(msg)
+	}
+}
+
+func signPrecomputed(msgs [][]byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	key.Precompute()
+
+	for _, msg := range msgs {
+		digest := sha256.Sum256(msg)
+		sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(sig)
+	}
+}
+
+func signOnce(msg []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	digest := sha256.Sum256(msg)
+	sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(sig)
+}
+
+func main() {
+	msgs := make([][]byte, len(os.Args))
+	for i, arg := range os.Args {
+		msgs[i] = []byte(arg)
+	}
+
+	signAll(msgs)
+	decryptAll(msgs)
+	signPrecomputed(msgs)
+	signOnce(msgs[0])
+}
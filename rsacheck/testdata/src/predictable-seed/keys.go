@@ -0,0 +1,33 @@
+package keys
+
+import (
+	"crypto/rsa"
+	"math/rand"
+	"time"
+)
+
+const seed = 42
+
+var fixedSeed int64 = 7
+
+func GenerateZeroSeedKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.New(rand.NewSource(0)), 2048) // want "math/rand is not cryptographically secure; use crypto/rand.Reader" "predictable seed makes key generation deterministic"
+}
+
+func GenerateConstSeedKey() (*rsa.PrivateKey, error) {
+	source := rand.NewSource(seed)
+	reader := rand.New(source)
+	return rsa.GenerateKey(reader, 2048) // want "math/rand is not cryptographically secure; use crypto/rand.Reader" "predictable seed makes key generation deterministic"
+}
+
+func GenerateVariableSeedKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.New(rand.NewSource(fixedSeed)), 2048) // want "math/rand is not cryptographically secure; use crypto/rand.Reader" "predictable seed makes key generation deterministic"
+}
+
+func GenerateTimeSeedKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.New(rand.NewSource(time.Now().UnixNano())), 2048) // want "math/rand is not cryptographically secure; use crypto/rand.Reader" "time-based seeds are predictable; use crypto/rand.Reader"
+}
+
+func GenerateParamSeedKey(seed int64) (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.New(rand.NewSource(seed)), 2048) // want "math/rand is not cryptographically secure; use crypto/rand.Reader"
+}
@@ -0,0 +1,60 @@
+package sign
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+)
+
+const saltLength = 16
+
+func SignSmallSalt(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: 8}) // want "PSS salt length is too small; 8 bytes is less than the 32-byte SHA-256 digest, use rsa.PSSSaltLengthEqualsHash"
+}
+
+func SignNamedSalt(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha512.Sum512(msg)
+	opts := &rsa.PSSOptions{SaltLength: saltLength}
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA512, digest[:], opts) // want "PSS salt length is too small; 16 bytes is less than the 64-byte SHA-512 digest, use rsa.PSSSaltLengthEqualsHash"
+}
+
+func SignAssignedSalt(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	opts := &rsa.PSSOptions{}
+	opts.SaltLength = 4
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], opts) // want "PSS salt length is too small; 4 bytes is less than the 32-byte SHA-256 digest, use rsa.PSSSaltLengthEqualsHash"
+}
+
+func SignEqualsHash(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+}
+
+func SignAuto(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+}
+
+func SignHashSizedSalt(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: 32})
+}
+
+func SignDefaultOptions(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+}
+
+func SignSaltParameter(key *rsa.PrivateKey, msg []byte, saltLength int) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: saltLength})
+}
+
+// Hashes that aren't known aren't checked, since their size is unknown.
+func SignUnknownHash(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPSS(rand.Reader, key, crypto.Hash(100), digest[:], &rsa.PSSOptions{SaltLength: 16})
+}
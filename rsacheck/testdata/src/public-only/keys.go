@@ -0,0 +1,35 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+// GenerateKey is part of the public API, so weak parameters are reported.
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
+
+// GenerateKeyWithBits passes its parameter to an unexported function, where
+// weak parameters are reported because they're controlled by the caller.
+func GenerateKeyWithBits(bits int) (*rsa.PrivateKey, error) {
+	return generate(bits)
+}
+
+// Closures in exported functions are also part of the public API.
+func Generator() func() (*rsa.PrivateKey, error) {
+	return func() (*rsa.PrivateKey, error) {
+		return rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+	}
+}
+
+func generate(bits int) (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, 2, bits) // want "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+}
+
+// testKey is only used internally, so it isn't reported.
+func testKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024)
+}
+
+var _, _ = testKey()
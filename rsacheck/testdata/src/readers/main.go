@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	cryptorand "crypto/rand"
+	"crypto/rsa"
+	"io"
+	"math/rand"
+	randv2 "math/rand/v2"
+	"strings"
+)
+
+// HSMReader is trusted using the -trusted-readers flag.
+var HSMReader io.Reader = cryptorand.Reader
+
+func secureCryptoRand() {
+	rsa.GenerateKey(cryptorand.Reader, 2048)
+}
+
+func mathRand() {
+	rsa.GenerateKey(rand.New(rand.NewSource(0)), 2048)
+}
+
+func deterministicBytes() {
+	rsa.GenerateKey(bytes.NewReader(make([]byte, 4096)), 2048)
+}
+
+func deterministicStrings() {
+	rsa.GenerateKey(strings.NewReader("not random"), 2048)
+}
+
+func deterministicChaCha8() {
+	rsa.GenerateKey(randv2.NewChaCha8([32]byte{}), 2048)
+}
+
+func customTrusted() {
+	rsa.GenerateKey(HSMReader, 2048)
+}
+
+func unknown(r io.Reader) {
+	rsa.GenerateKey(r, 2048)
+}
+
+func main() {}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"log"
+)
+
+func generate() (key *rsa.PrivateKey) {
+	defer func() {
+		recover()
+	}()
+
+	key, _ = rsa.GenerateKey(rand.Reader, 2048) // want "do not recover from panics around RSA key generation"
+	return key
+}
+
+func generateLogged() *rsa.PrivateKey {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("recovered:", r)
+		}
+	}()
+
+	key, _ := rsa.GenerateKey(rand.Reader, 2048) // want "do not recover from panics around RSA key generation"
+	return key
+}
+
+func generateChecked() (*rsa.PrivateKey, error) {
+	defer log.Println("generated key")
+
+	return rsa.GenerateKey(rand.Reader, 2048)
+}
+
+func main() {
+	generate()
+	generateLogged()
+	generateChecked()
+}
@@ -0,0 +1,10 @@
+package keys // want `internal error analyzing recovered-package-panic at .*keys\.go:1:1, which was skipped: unexpected SSA`
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
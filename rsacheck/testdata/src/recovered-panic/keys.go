@@ -0,0 +1,14 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+func Panics() (*rsa.PrivateKey, error) { // want `internal error analyzing recovered-panic.Panics at .*keys\.go:8:6, which was skipped: unexpected SSA`
+	return rsa.GenerateKey(rand.Reader, 1024)
+}
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
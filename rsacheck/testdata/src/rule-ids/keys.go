@@ -0,0 +1,23 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	mathrand "math/rand"
+)
+
+func GenerateWeakKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 1024) // want `^\[RSA002\] use 2048 bits or greater$`
+}
+
+func GenerateMultiPrimeKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateMultiPrimeKey(rand.Reader, 2, 2048) // want `^\[RSA004\] use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey$`
+}
+
+func GenerateMathRandKey(seed int64) (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(mathrand.New(mathrand.NewSource(seed)), 2048) // want `^\[RSA001\] math/rand is not cryptographically secure; use crypto/rand.Reader$`
+}
+
+func EncryptPKCS1v15(pub *rsa.PublicKey, msg []byte) ([]byte, error) {
+	return rsa.EncryptPKCS1v15(rand.Reader, pub, msg) // want `^\[RSA005\] use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15$`
+}
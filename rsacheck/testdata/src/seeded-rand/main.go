@@ -0,0 +1,20 @@
+package main
+
+import (
+	"crypto/rsa"
+	"math/rand"
+	"time"
+)
+
+func init() {
+	rand.Seed(time.Now().UnixNano())
+}
+
+func main() {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	_, err := rsa.GenerateKey(r, 2048) // want "the package seeds math/rand, so the random source is likely predictable" "time-based seeds are predictable; use crypto/rand.Reader"
+	if err != nil {
+		panic(err)
+	}
+}
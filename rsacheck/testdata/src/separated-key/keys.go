@@ -0,0 +1,44 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+)
+
+var (
+	signingKey    *rsa.PrivateKey
+	decryptionKey *rsa.PrivateKey
+)
+
+func Sign(digest []byte) ([]byte, error) {
+	return rsa.SignPSS(rand.Reader, signingKey, crypto.SHA256, digest, nil)
+}
+
+func Decrypt(ciphertext []byte) ([]byte, error) {
+	return rsa.DecryptOAEP(sha256.New(), rand.Reader, decryptionKey, ciphertext, nil)
+}
+
+type Server struct {
+	signingKey    *rsa.PrivateKey
+	decryptionKey *rsa.PrivateKey
+}
+
+func (s *Server) Sign(digest []byte) ([]byte, error) {
+	return rsa.SignPSS(rand.Reader, s.signingKey, crypto.SHA256, digest, nil)
+}
+
+func (s *Server) Decrypt(ciphertext []byte) ([]byte, error) {
+	return rsa.DecryptOAEP(sha256.New(), rand.Reader, s.decryptionKey, ciphertext, nil)
+}
+
+func SignBoth(k *rsa.PrivateKey, digest, other []byte) ([]byte, []byte, error) {
+	sig, err := rsa.SignPSS(rand.Reader, k, crypto.SHA256, digest, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	otherSig, err := rsa.SignPSS(rand.Reader, k, crypto.SHA256, other, nil)
+	return sig, otherSig, err
+}
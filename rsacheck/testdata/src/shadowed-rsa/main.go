@@ -0,0 +1,48 @@
+package main
+
+import (
+	"crypto/rand"
+	stdrsa "crypto/rsa"
+	"fmt"
+
+	"shadowed-rsa/rsa"
+)
+
+// Signer is implemented by keys with a method of the same name as an RSA function.
+type Signer interface {
+	SignPSS(digest []byte) ([]byte, error)
+}
+
+func sign(s Signer, digest []byte) ([]byte, error) {
+	return s.SignPSS(digest)
+}
+
+func main() {
+	key, err := rsa.GenerateKey(1024)
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err := rsa.GenerateMultiPrimeKey(3, 1024); err != nil {
+		panic(err)
+	}
+
+	sig, err := rsa.SignPSS(key, []byte("digest"))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(sig)
+
+	ciphertext, err := rsa.EncryptOAEP([]byte("message"))
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err := rsa.DecryptOAEP(key, ciphertext); err != nil {
+		panic(err)
+	}
+
+	if _, err := stdrsa.GenerateKey(rand.Reader, 1024); err != nil { // want "use 2048 bits or greater"
+		panic(err)
+	}
+}
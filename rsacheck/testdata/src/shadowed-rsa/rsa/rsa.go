@@ -0,0 +1,27 @@
+// Package rsa shadows the name of the "crypto/rsa" package, with functions of the
+// same names that take different arguments.
+package rsa
+
+type PrivateKey struct {
+	Bits int
+}
+
+func GenerateKey(bits int) (*PrivateKey, error) {
+	return &PrivateKey{Bits: bits}, nil
+}
+
+func GenerateMultiPrimeKey(nprimes, bits int) (*PrivateKey, error) {
+	return &PrivateKey{Bits: bits}, nil
+}
+
+func SignPSS(key *PrivateKey, digest []byte) ([]byte, error) {
+	return digest, nil
+}
+
+func EncryptOAEP(msg []byte) ([]byte, error) {
+	return msg, nil
+}
+
+func DecryptOAEP(key *PrivateKey, ciphertext []byte) ([]byte, error) {
+	return ciphertext, nil
+}
@@ -0,0 +1,53 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+)
+
+var key *rsa.PrivateKey // want "avoid reusing an RSA key for both signing and encryption"
+
+func Sign(digest []byte) ([]byte, error) {
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest, nil)
+}
+
+func Decrypt(ciphertext []byte) ([]byte, error) {
+	return rsa.DecryptOAEP(sha256.New(), rand.Reader, key, ciphertext, nil)
+}
+
+type Server struct {
+	key *rsa.PrivateKey // want "avoid reusing an RSA key for both signing and encryption"
+}
+
+func (s *Server) Sign(digest []byte) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest) // want "prefer rsa.SignPSS over rsa.SignPKCS1v15 for new code"
+}
+
+func (s *Server) Decrypt(ciphertext []byte) ([]byte, error) {
+	return rsa.DecryptOAEP(sha256.New(), rand.Reader, s.key, ciphertext, nil)
+}
+
+func RoundTrip(digest, ciphertext []byte) error {
+	k, err := rsa.GenerateKey(rand.Reader, 2048) // want "avoid reusing an RSA key for both signing and encryption"
+	if err != nil {
+		return err
+	}
+
+	if _, err := rsa.SignPSS(rand.Reader, k, crypto.SHA256, digest, nil); err != nil { // want "signature returned by rsa.SignPSS is never used"
+		return err
+	}
+
+	_, err = rsa.DecryptOAEP(sha256.New(), rand.Reader, k, ciphertext, nil)
+	return err
+}
+
+func SignAndDecrypt(k *rsa.PrivateKey, digest, ciphertext []byte) error { // want "avoid reusing an RSA key for both signing and encryption"
+	if _, err := rsa.SignPSS(rand.Reader, k, crypto.SHA256, digest, nil); err != nil { // want "signature returned by rsa.SignPSS is never used"
+		return err
+	}
+
+	_, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, k, ciphertext, nil)
+	return err
+}
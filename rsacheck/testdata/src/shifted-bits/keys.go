@@ -0,0 +1,31 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+const base = 256
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	bits := base
+	bits <<= 2
+	return rsa.GenerateKey(rand.Reader, bits) // want "use 2048 bits or greater"
+}
+
+func GenerateShiftedKey() (*rsa.PrivateKey, error) {
+	shift := 2
+	return rsa.GenerateKey(rand.Reader, base<<shift) // want "use 2048 bits or greater"
+}
+
+func GenerateStrongKey() (*rsa.PrivateKey, error) {
+	bits := base
+	bits <<= 4
+	return rsa.GenerateKey(rand.Reader, bits)
+}
+
+func GenerateUnalignedKey() (*rsa.PrivateKey, error) {
+	bits := 4100
+	bits >>= 1
+	return rsa.GenerateKey(rand.Reader, bits) // want "use a multiple of 8 bits for RSA keys"
+}
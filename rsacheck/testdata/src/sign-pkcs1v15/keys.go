@@ -0,0 +1,24 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+)
+
+func Sign(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:]) // want "prefer rsa.SignPSS over rsa.SignPKCS1v15 for new code"
+}
+
+func SignPSS(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+}
+
+// VerifyLegacy verifies signatures created by legacy clients.
+func VerifyLegacy(pub *rsa.PublicKey, msg, sig []byte) error {
+	digest := sha256.Sum256(msg)
+	return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig)
+}
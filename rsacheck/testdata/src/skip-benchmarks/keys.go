@@ -0,0 +1,11 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+// GenerateKey generates a new RSA key.
+func GenerateKey(bits int) (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, bits)
+}
@@ -0,0 +1,31 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+)
+
+func TestGenerateKey(t *testing.T) {
+	if _, err := rsa.GenerateKey(rand.Reader, 1024); err != nil { // want "use 2048 bits or greater"
+		t.Fatal(err)
+	}
+}
+
+func ExampleGenerateKey() {
+	rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+}
+
+func BenchmarkGenerateKey(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		rsa.GenerateKey(rand.Reader, 1024)
+	}
+}
+
+func BenchmarkGenerateKeySizes(b *testing.B) {
+	b.Run("1024", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rsa.GenerateKey(rand.Reader, 1024)
+		}
+	})
+}
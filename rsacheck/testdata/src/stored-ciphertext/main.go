@@ -0,0 +1,52 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"os"
+)
+
+func storeInDatabase(db *sql.DB, pub *rsa.PublicKey, secret []byte) error {
+	ciphertext, err := rsa.EncryptPKCS1v15(rand.Reader, pub, secret) // want "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15; the ciphertext is stored, which will make migrating away from it harder"
+	if err != nil {
+		return err
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(ciphertext)
+
+	_, err = db.Exec("INSERT INTO secrets (value) VALUES (?)", encoded)
+	return err
+}
+
+func storeInFile(pub *rsa.PublicKey, secret []byte) error {
+	ciphertext, err := rsa.EncryptPKCS1v15(rand.Reader, pub, secret) // want "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15; the ciphertext is stored, which will make migrating away from it harder"
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile("secret.txt", []byte(base64.URLEncoding.EncodeToString(ciphertext)), 0o600)
+}
+
+func printOnly(pub *rsa.PublicKey, secret []byte) error {
+	ciphertext, err := rsa.EncryptPKCS1v15(rand.Reader, pub, secret) // want "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15"
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(base64.StdEncoding.EncodeToString(ciphertext))
+	return nil
+}
+
+func main() {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := printOnly(&privateKey.PublicKey, []byte("secret")); err != nil {
+		panic(err)
+	}
+}
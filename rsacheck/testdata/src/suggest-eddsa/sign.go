@@ -0,0 +1,77 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+)
+
+func signToken(token []byte) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048) // want "RSA key is only used for signing; for new code, prefer ed25519.GenerateKey or ecdsa.GenerateKey, whose keys and signatures are smaller and faster"
+	if err != nil {
+		panic(err)
+	}
+
+	digest := sha256.Sum256(token)
+	sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := rsa.VerifyPSS(&key.PublicKey, crypto.SHA256, digest[:], sig, nil); err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+// signManifest is only reported once per package, at the first signing-only key.
+func signManifest(manifest []byte) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	digest := sha256.Sum256(manifest)
+	sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+func unwrap(ciphertext []byte) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	msg, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, key, ciphertext, nil)
+	if err != nil {
+		panic(err)
+	}
+	return msg
+}
+
+func newSigningKey() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	digest := sha256.Sum256([]byte("self-test"))
+	sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(sig)
+	return key
+}
+
+func main() {
+	fmt.Println(signToken([]byte("token")))
+	fmt.Println(signManifest([]byte("manifest")))
+	fmt.Println(unwrap(nil))
+	fmt.Println(newSigningKey())
+}
@@ -0,0 +1,38 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+	"unsafe"
+)
+
+func VerifySwapped(priv *rsa.PrivateKey, digest, sig []byte) error {
+	pub := (*rsa.PublicKey)(unsafe.Pointer(priv))
+	return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest, sig) // want "rsa.VerifyPKCS1v15 is called with a private key converted to a public key"
+}
+
+func EncryptSwapped(priv *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	return rsa.EncryptOAEP(sha256.New(), rand.Reader, (*rsa.PublicKey)(unsafe.Pointer(priv)), msg, nil) // want "rsa.EncryptOAEP is called with a private key converted to a public key"
+}
+
+func SignSwapped(pub *rsa.PublicKey, digest []byte) ([]byte, error) {
+	priv := (*rsa.PrivateKey)(unsafe.Pointer(pub))
+	return rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, digest) // want "rsa.SignPKCS1v15 is called with a private key that only holds a public key" "prefer rsa.SignPSS over rsa.SignPKCS1v15 for new code"
+}
+
+func SignPublicOnly(pub *rsa.PublicKey, digest []byte) ([]byte, error) {
+	priv := &rsa.PrivateKey{PublicKey: *pub}
+	return rsa.SignPSS(rand.Reader, priv, crypto.SHA256, digest, nil) // want "rsa.SignPSS is called with a private key that only holds a public key"
+}
+
+func Verify(priv *rsa.PrivateKey, digest, sig []byte) error {
+	return rsa.VerifyPKCS1v15(&priv.PublicKey, crypto.SHA256, digest, sig)
+}
+
+func Sign(pub *rsa.PublicKey, d *big.Int, digest []byte) ([]byte, error) {
+	priv := &rsa.PrivateKey{PublicKey: *pub, D: d}
+	return rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, digest) // want "prefer rsa.SignPSS over rsa.SignPKCS1v15 for new code"
+}
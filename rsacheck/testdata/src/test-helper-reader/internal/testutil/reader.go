@@ -0,0 +1,15 @@
+// Package testutil provides helpers for tests.
+package testutil
+
+import (
+	"bytes"
+	"io"
+)
+
+// Reader is a deterministic random reader, so that tests are reproducible.
+var Reader io.Reader = bytes.NewReader(make([]byte, 4096))
+
+// NewReader returns a deterministic random reader.
+func NewReader() io.Reader {
+	return bytes.NewReader(make([]byte, 4096))
+}
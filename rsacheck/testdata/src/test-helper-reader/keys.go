@@ -0,0 +1,25 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+
+	"test-helper-reader/internal/testutil"
+	"test-helper-reader/mocks"
+)
+
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(testutil.Reader, 2048) // want "random reader testutil.Reader is from test helper package test-helper-reader/internal/testutil"
+}
+
+func GenerateKeyFrom() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(testutil.NewReader(), 2048) // want "random reader testutil.NewReader\\(\\) is from test helper package"
+}
+
+func GenerateMockKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(mocks.Reader{}, 2048) // want "random reader mocks.Reader{} is from test helper package test-helper-reader/mocks"
+}
+
+func GenerateSecureKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 2048)
+}
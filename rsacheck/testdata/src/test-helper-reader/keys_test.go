@@ -0,0 +1,14 @@
+package keys
+
+import (
+	"crypto/rsa"
+	"testing"
+
+	"test-helper-reader/internal/testutil"
+)
+
+func TestGenerateKey(t *testing.T) {
+	if _, err := rsa.GenerateKey(testutil.Reader, 2048); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,10 @@
+// Package mocks provides mock implementations for tests.
+package mocks
+
+// Reader is a random reader that always returns zeros.
+type Reader struct{}
+
+func (Reader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
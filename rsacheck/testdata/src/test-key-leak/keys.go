@@ -0,0 +1,14 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+// SigningKey is the key used to sign tokens, loaded when the program starts.
+var SigningKey *rsa.PrivateKey
+
+func Sign(digest []byte) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand.Reader, SigningKey, crypto.SHA256, digest) // want "prefer rsa.SignPSS over rsa.SignPKCS1v15 for new code"
+}
@@ -0,0 +1,41 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"testing"
+)
+
+var testKey *rsa.PrivateKey
+
+func TestMain(m *testing.M) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	SigningKey = key // want "RSA key generated in TestMain is stored in package variable SigningKey, which is declared in non-test code"
+
+	testKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func ExampleSign() {
+	SigningKey, _ = rsa.GenerateKey(rand.Reader, 2048) // want "RSA key generated in ExampleSign is stored in package variable SigningKey"
+
+	sig, _ := Sign(make([]byte, 32))
+	fmt.Println(len(sig))
+}
+
+func TestSign(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SigningKey = key
+}
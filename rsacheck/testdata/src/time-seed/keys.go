@@ -0,0 +1,28 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	mathrand "math/rand"
+	"time"
+)
+
+func GenerateNanoSeeded() (*rsa.PrivateKey, error) {
+	r := mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
+	return rsa.GenerateKey(r, 2048) // want "math/rand is not cryptographically secure; use crypto/rand.Reader" "time-based seeds are predictable; use crypto/rand.Reader"
+}
+
+func GenerateUnixSeeded() (*rsa.PrivateKey, error) {
+	now := time.Now()
+	seed := now.Unix()
+	return rsa.GenerateKey(mathrand.New(mathrand.NewSource(seed)), 2048) // want "math/rand is not cryptographically secure; use crypto/rand.Reader" "time-based seeds are predictable; use crypto/rand.Reader"
+}
+
+func GenerateParameterSeeded(seed int64) (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(mathrand.New(mathrand.NewSource(seed)), 2048) // want "math/rand is not cryptographically secure; use crypto/rand.Reader"
+}
+
+func GenerateSecure() (*rsa.PrivateKey, error) {
+	_ = time.Now().UnixNano()
+	return rsa.GenerateKey(rand.Reader, 2048)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"os"
+)
+
+var (
+	key, _    = rsa.GenerateKey(rand.Reader, 2048)
+	macKey, _ = os.ReadFile("mac.key")
+)
+
+func decrypt(w http.ResponseWriter, r *http.Request) {
+	ciphertext, _ := io.ReadAll(r.Body)
+
+	plaintext, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, key, ciphertext, nil) // want "ciphertext from an untrusted source is decrypted without verifying a MAC or signature first"
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Write(plaintext)
+}
+
+func decryptPKCS1v15(w http.ResponseWriter, r *http.Request) {
+	ciphertext, _ := io.ReadAll(r.Body)
+
+	plaintext, _ := rsa.DecryptPKCS1v15(rand.Reader, key, ciphertext) // want "ciphertext from an untrusted source is decrypted without verifying a MAC or signature first" "PKCS#1 v1.5 decryption is vulnerable to padding oracles"
+	w.Write(plaintext)
+}
+
+func decryptAuthenticated(w http.ResponseWriter, r *http.Request) {
+	ciphertext, _ := io.ReadAll(r.Body)
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(ciphertext)
+	if !hmac.Equal(mac.Sum(nil), []byte(r.Header.Get("X-Signature"))) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	plaintext, _ := rsa.DecryptOAEP(sha256.New(), rand.Reader, key, ciphertext, nil)
+	w.Write(plaintext)
+}
+
+func decryptFile() {
+	ciphertext, _ := os.ReadFile("secret.enc")
+
+	plaintext, _ := rsa.DecryptOAEP(sha256.New(), rand.Reader, key, ciphertext, nil)
+	os.Stdout.Write(plaintext)
+}
+
+func main() {
+	http.HandleFunc("/decrypt", decrypt)
+	http.HandleFunc("/decrypt/pkcs1v15", decryptPKCS1v15)
+	http.HandleFunc("/decrypt/authenticated", decryptAuthenticated)
+	decryptFile()
+	http.ListenAndServe(":8080", nil)
+}
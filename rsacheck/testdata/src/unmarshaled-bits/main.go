@@ -0,0 +1,54 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+type config struct {
+	Key struct {
+		Bits int `json:"bits"`
+	} `json:"key"`
+}
+
+func generate(data []byte) (*rsa.PrivateKey, error) {
+	var cfg config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	return rsa.GenerateKey(rand.Reader, cfg.Key.Bits) // want "number of bits is unmarshaled, and may be controlled by users"
+}
+
+func generateDecoded() (*rsa.PrivateKey, error) {
+	var cfg config
+	if err := json.NewDecoder(os.Stdin).Decode(&cfg); err != nil {
+		return nil, err
+	}
+
+	return rsa.GenerateKey(rand.Reader, cfg.Key.Bits) // want "number of bits is unmarshaled, and may be controlled by users"
+}
+
+func generateValidated(data []byte) (*rsa.PrivateKey, error) {
+	var cfg config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg.Key.Bits < 2048 {
+		return nil, errors.New("key size must be at least 2048 bits")
+	}
+
+	return rsa.GenerateKey(rand.Reader, cfg.Key.Bits)
+}
+
+func main() {
+	data, _ := os.ReadFile("config.json")
+
+	generate(data)
+	generateDecoded()
+	generateValidated(data)
+}
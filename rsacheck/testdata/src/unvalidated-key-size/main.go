@@ -0,0 +1,73 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+func generate(bits int) *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits) // want "RSA key size is not constant; validate the size of the generated key using key.Size\\(\\) or key.N.BitLen\\(\\)"
+	if err != nil {
+		panic(err)
+	}
+	return privateKey
+}
+
+func generateValidated(bits int) *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		panic(err)
+	}
+
+	if privateKey.N.BitLen() < 2048 {
+		panic("key is too small")
+	}
+	return privateKey
+}
+
+func generateValidatedSize(bits int) *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		panic(err)
+	}
+
+	if privateKey.Size() < 256 {
+		panic("key is too small")
+	}
+	return privateKey
+}
+
+var pkgBits = 2048
+
+func generatePackageBits() *rsa.PrivateKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, pkgBits)
+	if err != nil {
+		panic(err)
+	}
+	return privateKey
+}
+
+func generateSelectedBits(large bool) *rsa.PrivateKey {
+	bits := 2048
+	if large {
+		bits = 4096
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		panic(err)
+	}
+	return privateKey
+}
+
+func main() {
+	bits, err := strconv.Atoi(os.Getenv("RSA_BITS"))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(generate(bits), generateValidated(bits), generateValidatedSize(bits), generatePackageBits(), generateSelectedBits(bits > 2048))
+}
@@ -0,0 +1,56 @@
+package keys
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+)
+
+const weakBits = 1024
+
+var (
+	legacyBits = 1024
+	keyBits    = weakBits
+	strongBits = 4096
+
+	// reassignedBits is changed by Configure, so its value isn't known.
+	reassignedBits = 1024
+)
+
+func Configure(bits int) {
+	reassignedBits = bits
+}
+
+func GenerateNamedConstantKey() (*rsa.PrivateKey, error) {
+	const bits = 1024
+	return rsa.GenerateKey(rand.Reader, bits) // want "use 2048 bits or greater"
+}
+
+func GeneratePackageConstantKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, weakBits) // want "use 2048 bits or greater"
+}
+
+func GeneratePackageVariableKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, legacyBits) // want "use 2048 bits or greater"
+}
+
+func GenerateIndirectVariableKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, keyBits) // want "use 2048 bits or greater"
+}
+
+func GenerateLocalVariableKey() (*rsa.PrivateKey, error) {
+	bits := 1024
+
+	defer func() {
+		_ = bits
+	}()
+
+	return rsa.GenerateKey(rand.Reader, bits) // want "use 2048 bits or greater"
+}
+
+func GenerateStrongKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, strongBits)
+}
+
+func GenerateReassignedKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, reassignedBits)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"hash"
+	"os"
+)
+
+func sign(key *rsa.PrivateKey, digest []byte, legacy bool) ([]byte, error) {
+	h := crypto.SHA256
+	if legacy {
+		h = crypto.SHA1
+	}
+
+	return rsa.SignPSS(rand.Reader, key, h, digest, nil) // want "hash may be SHA-1 depending on the path taken"
+}
+
+func verify(pub *rsa.PublicKey, digest, sig []byte, legacy bool) error {
+	h := crypto.SHA256
+	if legacy {
+		h = crypto.MD5
+	}
+
+	return rsa.VerifyPKCS1v15(pub, h, digest, sig) // want "hash may be MD5 depending on the path taken"
+}
+
+func encrypt(pub *rsa.PublicKey, msg []byte, legacy bool) ([]byte, error) {
+	var h hash.Hash
+	if legacy {
+		h = sha1.New()
+	} else {
+		h = sha256.New()
+	}
+
+	return rsa.EncryptOAEP(h, rand.Reader, pub, msg, nil) // want "hash may be SHA-1 depending on the path taken"
+}
+
+func signStrong(key *rsa.PrivateKey, digest []byte, large bool) ([]byte, error) {
+	h := crypto.SHA256
+	if large {
+		h = crypto.SHA512
+	}
+
+	return rsa.SignPSS(rand.Reader, key, h, digest, nil)
+}
+
+func main() {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	legacy := len(os.Args) > 1
+
+	sig, _ := sign(key, make([]byte, 32), legacy)
+	verify(&key.PublicKey, make([]byte, 32), sig, legacy)
+	encrypt(&key.PublicKey, []byte("hello"), legacy)
+	signStrong(key, make([]byte, 64), legacy)
+}
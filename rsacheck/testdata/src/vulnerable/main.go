@@ -3,6 +3,7 @@ package main
 import (
 	"crypto"
 	"crypto/rsa"
+	"crypto/sha1"
 	"fmt"
 	"math/rand"
 )
@@ -10,30 +11,42 @@ import (
 func main() {
 	r := rand.New(rand.NewSource(0))
 
-	privateKey, err := rsa.GenerateMultiPrimeKey(r, 9, 1024) // want "use the crypto/rand.Reader for a cryptographically secure random number generator" "use 2048 bits or greater" "for 1024 bits 3 is the max number of primes to use" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey"
+	privateKey, err := rsa.GenerateMultiPrimeKey(r, 9, 1024) // want "math/rand is not cryptographically secure; use crypto/rand.Reader" "predictable seed makes key generation deterministic" "use 2048 bits or greater" "for 1024 bits 3 is the max number of primes to use" "use rsa.GenerateKey instead of rsa.GenerateMultiPrimeKey" "RSA key is generated but overwritten before it.s used"
 	if err != nil {
 		panic(err)
 	}
 
-	privateKey, err = rsa.GenerateKey(r, 1024) // want "use the crypto/rand.Reader for a cryptographically secure random number generator" "use 2048 bits or greater"
+	privateKey, err = rsa.GenerateKey(r, 1024) // want "math/rand is not cryptographically secure; use crypto/rand.Reader" "predictable seed makes key generation deterministic" "use 2048 bits or greater" "avoid reusing an RSA key for both signing and encryption"
 	if err != nil {
 		panic(err)
 	}
 
 	msg := []byte("Thu Dec 19 18:06:16 EST 2013\n")
 
-	sig, err := rsa.SignPKCS1v15(nil, privateKey, crypto.Hash(0), msg)
+	sig, err := rsa.SignPKCS1v15(nil, privateKey, crypto.Hash(0), msg) // want "you must hash the message before signing" "prefer rsa.SignPSS over rsa.SignPKCS1v15 for new code"
 	if err != nil {
 		panic(err)
 	}
-	if err := rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.Hash(0), msg, sig); err != nil {
+	if err := rsa.VerifyPKCS1v15(&privateKey.PublicKey, crypto.Hash(0), msg, sig); err != nil { // want "do not sign/verify unhashed data; pass a real hash such as crypto.SHA256"
 		panic(err)
 	}
 
-	eMesg, err := rsa.EncryptPKCS1v15(r, &privateKey.PublicKey, msg) // want "use the crypto/rand.Reader for a cryptographically secure random number generator" "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15"
+	eMesg, err := rsa.EncryptPKCS1v15(r, &privateKey.PublicKey, msg) // want "math/rand is not cryptographically secure; use crypto/rand.Reader" "predictable seed makes key generation deterministic" "use rsa.EncryptOAEP instead of rsa.EncryptPKCS1v15"
 	if err != nil {
 		panic(err)
 	}
 
 	fmt.Println(eMesg)
+
+	oaepMesg, err := rsa.EncryptOAEP(sha1.New(), nil, &privateKey.PublicKey, msg, nil) // want "use a SHA-256 or stronger hash with OAEP instead of SHA-1"
+	if err != nil {
+		panic(err)
+	}
+
+	plaintext, err := rsa.DecryptOAEP(sha1.New(), nil, privateKey, oaepMesg, nil) // want "use a SHA-256 or stronger hash with OAEP instead of SHA-1"
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(plaintext)
 }
@@ -0,0 +1,13 @@
+package keys
+
+import (
+	"crypto/rsa"
+	"io"
+)
+
+// GenerateKey generates a new RSA key, using the random reader of the platform.
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(reader(), 2048) // want "use the crypto/rand.Reader instead of a fallback random reader for WebAssembly"
+}
+
+var _ io.Reader = reader()
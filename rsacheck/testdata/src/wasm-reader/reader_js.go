@@ -0,0 +1,12 @@
+package keys
+
+import (
+	"io"
+	"math/rand"
+	"time"
+)
+
+// reader falls back to math/rand, since crypto/rand was assumed to be unavailable.
+func reader() io.Reader {
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
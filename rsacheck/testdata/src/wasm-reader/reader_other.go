@@ -0,0 +1,12 @@
+//go:build !js
+
+package keys
+
+import (
+	"crypto/rand"
+	"io"
+)
+
+func reader() io.Reader {
+	return rand.Reader
+}
@@ -0,0 +1,13 @@
+//go:build js && wasm
+
+package keys
+
+import (
+	"crypto/rsa"
+	"math/rand"
+)
+
+// GenerateFallbackKey generates a new RSA key in the browser.
+func GenerateFallbackKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.New(rand.NewSource(1)), 2048) // want "use the crypto/rand.Reader instead of a fallback random reader for WebAssembly"
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net/http"
+)
+
+func certificate() []byte {
+	key, _ := rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+
+	template := &x509.Certificate{SerialNumber: big.NewInt(1)}
+
+	der, _ := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key) // want "RSA key with 1024 bits is used for an X.509 certificate"
+	return der
+}
+
+func main() {
+	key, _ := rsa.GenerateKey(rand.Reader, 1024) // want "use 2048 bits or greater"
+
+	cert := tls.Certificate{
+		Certificate: [][]byte{certificate()},
+		PrivateKey:  key, // want "RSA key with 1024 bits is used for TLS"
+	}
+
+	server := &http.Server{
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+	}
+	server.ListenAndServeTLS("", "")
+}
@@ -0,0 +1,26 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+)
+
+func SignDigest(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.Hash(0), digest[:]) // want "do not sign/verify unhashed data" "prefer rsa.SignPSS over rsa.SignPKCS1v15 for new code"
+}
+
+func VerifyDigest(pub *rsa.PublicKey, digest, sig []byte) error {
+	return rsa.VerifyPKCS1v15(pub, 0, digest, sig) // want "do not sign/verify unhashed data"
+}
+
+func Sign(key *rsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+	return rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:]) // want "prefer rsa.SignPSS over rsa.SignPKCS1v15 for new code"
+}
+
+func Verify(pub *rsa.PublicKey, digest, sig []byte) error {
+	return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest, sig)
+}
@@ -0,0 +1,74 @@
+package rsacheck
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// testHelperPackage reports whether the package is a test helper package, such as
+// "testutil" or "mocks", by its name. Such packages aren't _test.go files, so they
+// can be imported by production code.
+func testHelperPackage(pkg *types.Package) bool {
+	name := pkg.Name()
+	return strings.HasSuffix(name, "testutil") || strings.HasSuffix(name, "mocks")
+}
+
+// checkTestHelperReader checks if the random reader given to an RSA function in a
+// non-test file is a variable, function result, or type from a test helper package,
+// if enabled. Test helpers commonly provide deterministic readers, which are meant for
+// tests, but can leak into production code since the package is importable.
+//
+// It reports whether the finding was reported.
+func checkTestHelperReader(pass *analysis.Pass, instr *ssa.Call, value ssa.Value) bool {
+	if !configOf(pass).TestHelperReader {
+		return false
+	}
+
+	file := pass.Fset.File(instr.Pos())
+	if file == nil || strings.HasSuffix(file.Name(), "_test.go") {
+		return false
+	}
+
+	var (
+		pkg  *types.Package
+		name string
+	)
+
+	switch value := unwrapInterface(value).(type) {
+	case *ssa.UnOp:
+		global, ok := value.X.(*ssa.Global)
+		if value.Op != token.MUL || !ok {
+			return false
+		}
+		pkg, name = global.Pkg.Pkg, global.Name()
+	case *ssa.Call:
+		callee := value.Call.StaticCallee()
+		if callee == nil || callee.Pkg == nil {
+			return false
+		}
+		pkg, name = callee.Pkg.Pkg, callee.Name()+"()"
+	default:
+		typ := value.Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+
+		named, ok := typ.(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			return false
+		}
+		pkg, name = named.Obj().Pkg(), named.Obj().Name()+"{}"
+	}
+
+	if pkg == pass.Pkg || !testHelperPackage(pkg) {
+		return false
+	}
+
+	reportf(pass, instr.Pos(), testHelperReaderMessage, fmt.Sprintf("%v.%v", pkg.Name(), name), pkg.Path())
+	return true
+}
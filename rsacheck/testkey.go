@@ -0,0 +1,72 @@
+package rsacheck
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// testSetupFunc returns the name of the TestMain or Example function in a _test.go file
+// that the given function is, or is a closure within.
+func testSetupFunc(pass *analysis.Pass, fn *ssa.Function) (string, bool) {
+	for fn.Parent() != nil {
+		fn = fn.Parent()
+	}
+
+	if fn.Signature.Recv() != nil || (fn.Name() != "TestMain" && !strings.HasPrefix(fn.Name(), "Example")) {
+		return "", false
+	}
+
+	return fn.Name(), testFile(pass, fn.Pos())
+}
+
+// testFile reports whether the given position is in a _test.go file.
+func testFile(pass *analysis.Pass, pos token.Pos) bool {
+	file := pass.Fset.File(pos)
+	return file != nil && strings.HasSuffix(file.Name(), "_test.go")
+}
+
+// checkTestKeyLeak checks if a key generated in a TestMain or Example function is stored
+// in a package variable declared in a non-test file, if enabled. Such a variable is also
+// used by production code, where a test key, often generated with weak parameters for
+// speed, could leak if the variable is read before it's set by the program.
+func checkTestKeyLeak(pass *analysis.Pass, instr *ssa.Call) {
+	if !configOf(pass).TestKeyLeak {
+		return
+	}
+
+	name, ok := testSetupFunc(pass, instr.Parent())
+	if !ok {
+		return
+	}
+
+	for _, ref := range *instr.Referrers() {
+		key, ok := ref.(*ssa.Extract)
+		if !ok || key.Index != 0 {
+			continue
+		}
+
+		for _, ref := range *key.Referrers() {
+			store, ok := ref.(*ssa.Store)
+			if !ok || store.Val != key {
+				continue
+			}
+
+			global, ok := store.Addr.(*ssa.Global)
+			if !ok || global.Pkg.Pkg != pass.Pkg || testFile(pass, global.Pos()) {
+				continue
+			}
+
+			report(pass, testKeyLeakMessage, analysis.Diagnostic{
+				Pos:     store.Pos(),
+				Message: fmt.Sprintf(testKeyLeakMessage, name, global.Name()),
+				Related: []analysis.RelatedInformation{
+					{Pos: instr.Pos(), Message: "the key is generated here"},
+				},
+			})
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package rsacheck
+
+import (
+	"go/token"
+	"go/types"
+	"iter"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// callTo returns the call that produced the given value if it is a call to one of
+// the named functions. Tuple extraction, slicing, and type conversions are followed
+// back to the originating call, but only within the same function.
+func callTo(value ssa.Value, names ...string) (*ssa.Call, bool) {
+	switch value := value.(type) {
+	case *ssa.Call:
+		callee := value.Call.Value.String()
+		for _, name := range names {
+			if callee == name {
+				return value, true
+			}
+		}
+	case *ssa.Extract:
+		return callTo(value.Tuple, names...)
+	case *ssa.Slice:
+		return callTo(value.X, names...)
+	case *ssa.ChangeType:
+		return callTo(value.X, names...)
+	case *ssa.Convert:
+		return callTo(value.X, names...)
+	case *ssa.MakeInterface:
+		return callTo(value.X, names...)
+	}
+	return nil, false
+}
+
+// calleeName returns the fully qualified name of the function called by the given
+// call, such as "crypto/rsa.GenerateKey". Besides static calls, calls of function
+// values that can be resolved statically are followed to the function they hold, such
+// as a local variable, or a package-level variable that's never reassigned, assigned
+// rsa.GenerateKey, or a conversion of it to a named function type. Other dynamic calls,
+// such as interface method calls, return the string of the called value.
+func calleeName(call *ssa.Call) string {
+	if callee := call.Call.StaticCallee(); callee != nil {
+		return callee.String()
+	}
+
+	if call.Call.IsInvoke() {
+		return call.Call.Value.String()
+	}
+
+	if fn, ok := resolveFunc(call.Call.Value); ok {
+		return fn.String()
+	}
+
+	return call.Call.Value.String()
+}
+
+// resolveFunc returns the function held by the given function value, if it can be
+// resolved statically.
+func resolveFunc(value ssa.Value) (*ssa.Function, bool) {
+	switch value := value.(type) {
+	case *ssa.Function:
+		return value, true
+	case *ssa.ChangeType:
+		return resolveFunc(value.X)
+	case *ssa.UnOp:
+		if value.Op != token.MUL {
+			return nil, false
+		}
+
+		if global, ok := value.X.(*ssa.Global); ok {
+			declared, ok := declaredValue(global)
+			if !ok {
+				return nil, false
+			}
+			return resolveFunc(declared)
+		}
+
+		if stored := storedValue(value); stored != value {
+			return resolveFunc(stored)
+		}
+	}
+
+	return nil, false
+}
+
+// unwrapInterface returns the concrete value wrapped by interface conversions.
+func unwrapInterface(value ssa.Value) ssa.Value {
+	for {
+		switch v := value.(type) {
+		case *ssa.MakeInterface:
+			value = v.X
+		case *ssa.ChangeInterface:
+			value = v.X
+		default:
+			return value
+		}
+	}
+}
+
+// flowsTo returns the first call to one of the named functions that the given value
+// is passed to. Type conversions, slicing, and variadic arguments are followed
+// forward to their uses, but only within the same function.
+func flowsTo(value ssa.Value, names ...string) (*ssa.Call, bool) {
+	return flowsToVisit(value, names, map[ssa.Value]bool{})
+}
+
+func flowsToVisit(value ssa.Value, names []string, seen map[ssa.Value]bool) (*ssa.Call, bool) {
+	if seen[value] || value.Referrers() == nil {
+		return nil, false
+	}
+	seen[value] = true
+
+	for _, instr := range *value.Referrers() {
+		var next ssa.Value
+
+		switch instr := instr.(type) {
+		case *ssa.Call:
+			if call, ok := callTo(instr, names...); ok {
+				return call, true
+			}
+		case *ssa.Convert:
+			next = instr
+		case *ssa.ChangeType:
+			next = instr
+		case *ssa.MakeInterface:
+			next = instr
+		case *ssa.Slice:
+			next = instr
+		case *ssa.Store:
+			// Values stored into an array, such as the implicit array
+			// of variadic arguments, flow to the uses of that array.
+			if addr, ok := instr.Addr.(*ssa.IndexAddr); ok && instr.Val == value {
+				next = addr.X
+			}
+		}
+
+		if next == nil {
+			continue
+		}
+
+		if call, ok := flowsToVisit(next, names, seen); ok {
+			return call, true
+		}
+	}
+
+	return nil, false
+}
+
+// possibleValues returns the values that the given value may have, by following
+// φ-nodes to their edges, such as a variable assigned in different branches.
+// Values that aren't φ-nodes are returned as is.
+func possibleValues(value ssa.Value) []ssa.Value {
+	var (
+		values []ssa.Value
+		seen   = map[ssa.Value]bool{}
+		visit  func(ssa.Value)
+	)
+
+	visit = func(value ssa.Value) {
+		if seen[value] {
+			return
+		}
+		seen[value] = true
+
+		phi, ok := value.(*ssa.Phi)
+		if !ok {
+			values = append(values, value)
+			return
+		}
+
+		for _, edge := range phi.Edges {
+			visit(edge)
+		}
+	}
+
+	visit(value)
+
+	return values
+}
+
+// storedValue returns the value stored to a local variable, if the given value loads
+// a variable that's assigned exactly once, such as a variable whose address is taken
+// or that's captured by a closure. Interface conversions are followed, and otherwise
+// the given value is returned.
+func storedValue(value ssa.Value) ssa.Value {
+	load, ok := unwrapInterface(value).(*ssa.UnOp)
+	if !ok || load.Op != token.MUL {
+		return value
+	}
+
+	alloc, ok := load.X.(*ssa.Alloc)
+	if !ok {
+		return value
+	}
+
+	var store *ssa.Store
+
+	for _, ref := range *alloc.Referrers() {
+		switch ref := ref.(type) {
+		case *ssa.Store:
+			if store != nil {
+				return value
+			}
+			store = ref
+		case *ssa.UnOp, *ssa.DebugRef:
+		case *ssa.MakeClosure:
+			// Closures may assign the variable they capture.
+			if assignsFreeVar(ref, alloc) {
+				return value
+			}
+		default:
+			// The address escapes, so the variable may be assigned elsewhere.
+			return value
+		}
+	}
+
+	if store == nil || store.Addr != alloc {
+		return value
+	}
+
+	return storedValue(store.Val)
+}
+
+// assignsFreeVar reports whether the closure stores to the free variable bound to the
+// given variable.
+func assignsFreeVar(closure *ssa.MakeClosure, alloc *ssa.Alloc) bool {
+	fn := closure.Fn.(*ssa.Function)
+
+	for i, binding := range closure.Bindings {
+		if binding != alloc {
+			continue
+		}
+
+		for _, ref := range *fn.FreeVars[i].Referrers() {
+			if _, ok := ref.(*ssa.UnOp); !ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// declaredValue returns the value a package-level variable is declared with, if it's
+// never reassigned by functions of its package, such as var bits = 1024.
+func declaredValue(global *ssa.Global) (ssa.Value, bool) {
+	var value ssa.Value
+
+	for fn := range packageFuncs(global.Pkg) {
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				store, ok := instr.(*ssa.Store)
+				if !ok || store.Addr != global {
+					continue
+				}
+
+				// Only the package initializer stores the declared value.
+				if fn.Synthetic != "package initializer" || value != nil {
+					return nil, false
+				}
+				value = store.Val
+			}
+		}
+	}
+
+	return value, value != nil
+}
+
+// packageFuncs yields the functions declared in the package, including its package
+// initializer, methods, and function literals.
+func packageFuncs(pkg *ssa.Package) iter.Seq[*ssa.Function] {
+	return func(yield func(*ssa.Function) bool) {
+		var visit func(fn *ssa.Function) bool
+		visit = func(fn *ssa.Function) bool {
+			if !yield(fn) {
+				return false
+			}
+			for _, anon := range fn.AnonFuncs {
+				if !visit(anon) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for _, member := range pkg.Members {
+			init, ok := member.(*ssa.Function)
+			if !ok || init.Synthetic != "package initializer" {
+				continue
+			}
+
+			// Declared init functions aren't members of the package, and are
+			// only called by its initializer.
+			for _, b := range init.Blocks {
+				for _, instr := range b.Instrs {
+					call, ok := instr.(*ssa.Call)
+					if !ok {
+						continue
+					}
+					if fn := call.Call.StaticCallee(); fn != nil && fn.Pkg == pkg && strings.HasPrefix(fn.Name(), "init#") {
+						if !visit(fn) {
+							return
+						}
+					}
+				}
+			}
+		}
+
+		for _, member := range pkg.Members {
+			switch member := member.(type) {
+			case *ssa.Function:
+				if !visit(member) {
+					return
+				}
+			case *ssa.Type:
+				named, ok := member.Type().(*types.Named)
+				if !ok {
+					continue
+				}
+				for i := range named.NumMethods() {
+					fn := pkg.Prog.FuncValue(named.Method(i))
+					if fn == nil {
+						continue
+					}
+					if !visit(fn) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package rsacheck
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// unmarshalFunctions decode data, such as configuration, into a value.
+var unmarshalFunctions = []string{
+	"encoding/json.Unmarshal",
+	"(*encoding/json.Decoder).Decode",
+	"encoding/xml.Unmarshal",
+	"gopkg.in/yaml.v3.Unmarshal",
+	"google.golang.org/protobuf/proto.Unmarshal",
+	"github.com/golang/protobuf/proto.Unmarshal",
+}
+
+// checkUnmarshaledBits checks if the number of bits is a field of a struct that is
+// unmarshaled in the same function, such as a key size read from a JSON configuration,
+// without checking it against a minimum. Unmarshaled values are fully dynamic, and
+// often controlled by users.
+func checkUnmarshaledBits(pass *analysis.Pass, instr *ssa.Call, bits ssa.Value) {
+	if !configOf(pass).UnmarshaledBits {
+		return
+	}
+
+	field, ok := loadedField(bits)
+	if !ok {
+		return
+	}
+
+	root := field.X
+	for {
+		addr, ok := root.(*ssa.FieldAddr)
+		if !ok {
+			break
+		}
+		root = addr.X
+	}
+
+	alloc, ok := root.(*ssa.Alloc)
+	if !ok {
+		return
+	}
+
+	unmarshal, ok := flowsTo(alloc, unmarshalFunctions...)
+	if !ok || fieldCompared(instr.Parent(), field) {
+		return
+	}
+
+	report(pass, unmarshaledBitsMessage, analysis.Diagnostic{
+		Pos:     instr.Pos(),
+		Message: fmt.Sprintf(unmarshaledBitsMessage, configOf(pass).MinBits),
+		Related: []analysis.RelatedInformation{
+			{Pos: unmarshal.Pos(), Message: "number of bits is unmarshaled here"},
+		},
+	})
+}
+
+// loadedField returns the address of the struct field that the given value is loaded from.
+func loadedField(value ssa.Value) (*ssa.FieldAddr, bool) {
+	load, ok := value.(*ssa.UnOp)
+	if !ok || load.Op != token.MUL {
+		return nil, false
+	}
+
+	field, ok := load.X.(*ssa.FieldAddr)
+	return field, ok
+}
+
+// fieldCompared reports whether the same field of the same struct as the given address
+// is compared to another value anywhere in the function, such as a minimum key size.
+func fieldCompared(fn *ssa.Function, field *ssa.FieldAddr) bool {
+	same := func(value ssa.Value) bool {
+		other, ok := loadedField(value)
+		return ok && sameAddr(other, field)
+	}
+
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			binop, ok := instr.(*ssa.BinOp)
+			if !ok {
+				continue
+			}
+
+			switch binop.Op {
+			case token.LSS, token.LEQ, token.GTR, token.GEQ:
+				if same(binop.X) || same(binop.Y) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// sameAddr reports whether the given addresses refer to the same field, since each
+// access to a field of a struct, such as cfg.Key.Bits, results in new instructions.
+func sameAddr(a, b ssa.Value) bool {
+	x, ok := a.(*ssa.FieldAddr)
+	if !ok {
+		return a == b
+	}
+
+	y, ok := b.(*ssa.FieldAddr)
+	return ok && x.Field == y.Field && sameAddr(x.X, y.X)
+}
@@ -0,0 +1,81 @@
+package rsacheck
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// keyUses maps functions that use a private key to a description of what it's used for.
+var keyUses = map[string]string{
+	"crypto/x509.CreateCertificate":                      "an X.509 certificate",
+	"crypto/x509.CreateCertificateRequest":               "an X.509 certificate request",
+	"golang.org/x/crypto/ssh.NewSignerFromKey":           "SSH",
+	"golang.org/x/crypto/ssh.NewSignerFromSigner":        "SSH",
+	"(*github.com/golang-jwt/jwt/v4.Token).SignedString": "a JWT",
+	"(*github.com/golang-jwt/jwt/v5.Token).SignedString": "a JWT",
+}
+
+// checkWeakKeyUse checks if a key generated with a weak number of bits is used for TLS,
+// X.509 certificates, SSH, or JWTs in the same function. The finding is reported where
+// the key is used, with related information pointing to both where the key is generated
+// and where it's used, so editors can navigate between them.
+func checkWeakKeyUse(pass *analysis.Pass, instr *ssa.Call, bits int64) {
+	for _, ref := range *instr.Referrers() {
+		key, ok := ref.(*ssa.Extract)
+		if !ok || key.Index != 0 {
+			continue
+		}
+
+		pos, use, ok := keyUse(key)
+		if !ok {
+			continue
+		}
+
+		report(pass, weakKeyUseMessage, analysis.Diagnostic{
+			Pos:     pos,
+			Message: fmt.Sprintf(weakKeyUseMessage, bits, use, configOf(pass).MinBits),
+			Related: []analysis.RelatedInformation{
+				{Pos: instr.Pos(), Message: fmt.Sprintf("key with %v bits is generated here", bits)},
+				{Pos: pos, Message: fmt.Sprintf("and used for %v here", use)},
+			},
+		})
+	}
+}
+
+// keyUse returns the position and description of the first use of the given private key,
+// either passed to one of the [keyUses] functions, or stored as the PrivateKey of a
+// [crypto/tls.Certificate].
+func keyUse(key ssa.Value) (token.Pos, string, bool) {
+	names := make([]string, 0, len(keyUses))
+	for name := range keyUses {
+		names = append(names, name)
+	}
+
+	if call, ok := flowsTo(key, names...); ok {
+		return call.Pos(), keyUses[call.Call.Value.String()], true
+	}
+
+	for _, ref := range *key.Referrers() {
+		iface, ok := ref.(*ssa.MakeInterface)
+		if !ok {
+			continue
+		}
+
+		for _, ref := range *iface.Referrers() {
+			store, ok := ref.(*ssa.Store)
+			if !ok {
+				continue
+			}
+
+			addr, ok := store.Addr.(*ssa.FieldAddr)
+			if ok && fieldName(addr) == "PrivateKey" && isType(addr.X.Type(), "crypto/tls", "Certificate") {
+				return store.Pos(), "TLS", true
+			}
+		}
+	}
+
+	return token.NoPos, "", false
+}
@@ -0,0 +1,114 @@
+package rsacheck
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+const (
+	createCertificate = "crypto/x509.CreateCertificate"
+	timeAdd           = "(time.Time).Add"
+	timeAddDate       = "(time.Time).AddDate"
+)
+
+// longLivedBits is the number of bits recommended for keys that must remain secure beyond
+// 2030, as per NIST SP 800-57 Part 1 Rev. 5. Keys with fewer bits, but at least the minimum,
+// are only reported if they're used for a certificate valid for more than maxValidityYears.
+const (
+	longLivedBits    = 3072
+	maxValidityYears = 10
+)
+
+// checkLongValidity checks if a key generated with a number of bits that's acceptable today,
+// such as 2048, is used to create an X.509 certificate in the same function that's valid for
+// decades, which the key's strength may not outlast.
+//
+// The validity of the certificate must be set in its template as a constant offset, such as
+// NotAfter: now.AddDate(50, 0, 0).
+func checkLongValidity(pass *analysis.Pass, instr *ssa.Call, bits int64) {
+	if bits >= longLivedBits {
+		return
+	}
+
+	for _, ref := range *instr.Referrers() {
+		key, ok := ref.(*ssa.Extract)
+		if !ok || key.Index != 0 {
+			continue
+		}
+
+		create, ok := flowsTo(key, createCertificate)
+		if !ok {
+			continue
+		}
+
+		validity, ok := certificateValidity(create.Call.Args[1])
+		if !ok || validity <= maxValidityYears*365*24*time.Hour {
+			continue
+		}
+
+		years := int64(validity / (365 * 24 * time.Hour))
+
+		report(pass, longValidityMessage, analysis.Diagnostic{
+			Pos:     create.Pos(),
+			Message: fmt.Sprintf(longValidityMessage, bits, years, longLivedBits),
+			Related: []analysis.RelatedInformation{
+				{Pos: instr.Pos(), Message: fmt.Sprintf("key with %v bits is generated here", bits)},
+			},
+		})
+	}
+}
+
+// certificateValidity returns how long the certificate created from the given template is
+// valid for, if its NotAfter field is set to a constant offset of a time.
+func certificateValidity(template ssa.Value) (time.Duration, bool) {
+	alloc, ok := template.(*ssa.Alloc)
+	if !ok || !isType(alloc.Type(), "crypto/x509", "Certificate") {
+		return 0, false
+	}
+
+	for _, ref := range *alloc.Referrers() {
+		addr, ok := ref.(*ssa.FieldAddr)
+		if !ok || fieldName(addr) != "NotAfter" {
+			continue
+		}
+
+		for _, ref := range *addr.Referrers() {
+			store, ok := ref.(*ssa.Store)
+			if !ok || store.Addr != addr {
+				continue
+			}
+
+			return timeOffset(store.Val)
+		}
+	}
+
+	return 0, false
+}
+
+// timeOffset returns the constant offset added to a time by a call to [time.Time.Add] or
+// [time.Time.AddDate], approximating years as 365 days, and months as 30 days.
+func timeOffset(value ssa.Value) (time.Duration, bool) {
+	call, ok := callTo(value, timeAdd, timeAddDate)
+	if !ok {
+		return 0, false
+	}
+
+	var offsets []int64
+	for _, arg := range call.Call.Args[1:] {
+		c, ok := arg.(*ssa.Const)
+		if !ok || c.Value == nil {
+			return 0, false
+		}
+		offsets = append(offsets, c.Int64())
+	}
+
+	if call.Call.Value.String() == timeAdd {
+		return time.Duration(offsets[0]), true
+	}
+
+	day := 24 * time.Hour
+	return time.Duration(offsets[0])*365*day + time.Duration(offsets[1])*30*day + time.Duration(offsets[2])*day, true
+}
@@ -0,0 +1,82 @@
+package rsacheck
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/token"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// wasmTags are build tags that select a WebAssembly target, where crypto/rand.Reader
+// is backed by the host, such as the browser's crypto.getRandomValues.
+var wasmTags = map[string]bool{
+	"js":     true,
+	"wasm":   true,
+	"wasip1": true,
+}
+
+// wasmFileSuffixes are file name suffixes that select a WebAssembly target.
+var wasmFileSuffixes = []string{
+	"_js.go",
+	"_wasm.go",
+	"_wasip1.go",
+	"_js_test.go",
+	"_wasm_test.go",
+	"_wasip1_test.go",
+}
+
+// wasmFallback reports whether the weak random reader given to an RSA function is a
+// fallback for WebAssembly, because the call is in a file only built for WebAssembly,
+// or the reader is returned by a function declared in such a file.
+func wasmFallback(pass *analysis.Pass, instr *ssa.Call, value ssa.Value) bool {
+	if wasmOnly(pass, instr.Pos()) {
+		return true
+	}
+
+	call, ok := unwrapInterface(value).(*ssa.Call)
+	if !ok {
+		return false
+	}
+
+	callee := call.Call.StaticCallee()
+	return callee != nil && callee.Pkg != nil && callee.Pkg.Pkg == pass.Pkg && wasmOnly(pass, callee.Pos())
+}
+
+// wasmOnly reports whether the given position is in a file that is only built for
+// WebAssembly, by its build constraint or file name.
+func wasmOnly(pass *analysis.Pass, pos token.Pos) bool {
+	file, ok := fileOf(pass, pos)
+	if !ok {
+		return false
+	}
+
+	name := filepath.Base(pass.Fset.File(file.Pos()).Name())
+	for _, suffix := range wasmFileSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+
+	return wasmFile(file)
+}
+
+// wasmFile reports whether the given file's build constraint requires a WebAssembly target.
+func wasmFile(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+
+		for _, comment := range group.List {
+			expr, err := constraint.Parse(comment.Text)
+			if err == nil && requiresTags(expr, wasmTags) {
+				return true
+			}
+		}
+	}
+	return false
+}